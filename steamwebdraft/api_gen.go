@@ -0,0 +1,340 @@
+// Code generated by apigen from supported_api_list.json. DO NOT EDIT.
+
+package steamweb
+
+import (
+	"context"
+)
+
+// GameServersServiceGetServerIPsBySteamIDV1Params are params of IGameServersService/GetServerIPsBySteamID/v1.
+type GameServersServiceGetServerIPsBySteamIDV1Params struct {
+	ServerSteamIDs uint64 `json:"server_steamids" steam:"server_steamids"`
+}
+
+// GameServersServiceGetServerIPsBySteamIDV1 calls IGameServersService/GetServerIPsBySteamID/v1.
+// Gets a list of server IP addresses given a list of SteamIDs.
+// Response is decoded into out, see Client.Do.
+func (c *Client) GameServersServiceGetServerIPsBySteamIDV1(ctx context.Context, params *GameServersServiceGetServerIPsBySteamIDV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("IGameServersService", "GetServerIPsBySteamID", 1, "GET", params), out)
+}
+
+// GameServersServiceGetServerListV1Params are params of IGameServersService/GetServerList/v1.
+type GameServersServiceGetServerListV1Params struct {
+	// Query filter string.
+	Filter string `json:"filter,omitempty" steam:"filter,optional"`
+	// The maximum number of servers to return in the response.
+	Limit uint32 `json:"limit,omitempty" steam:"limit,optional"`
+}
+
+// GameServersServiceGetServerListV1 calls IGameServersService/GetServerList/v1.
+// Gets a list of servers given a filter string.
+// Response is decoded into out, see Client.Do.
+func (c *Client) GameServersServiceGetServerListV1(ctx context.Context, params *GameServersServiceGetServerListV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("IGameServersService", "GetServerList", 1, "GET", params), out)
+}
+
+// GameServersServiceGetServerSteamIDsByIPV1Params are params of IGameServersService/GetServerSteamIDsByIP/v1.
+type GameServersServiceGetServerSteamIDsByIPV1Params struct {
+	ServerIPs string `json:"server_ips" steam:"server_ips"`
+}
+
+// GameServersServiceGetServerSteamIDsByIPV1 calls IGameServersService/GetServerSteamIDsByIP/v1.
+// Gets a list of server SteamIDs given a list of IPs.
+// Response is decoded into out, see Client.Do.
+func (c *Client) GameServersServiceGetServerSteamIDsByIPV1(ctx context.Context, params *GameServersServiceGetServerSteamIDsByIPV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("IGameServersService", "GetServerSteamIDsByIP", 1, "GET", params), out)
+}
+
+// PlayerServiceGetBadgesV1Params are params of IPlayerService/GetBadges/v1.
+type PlayerServiceGetBadgesV1Params struct {
+	// The player we're asking about.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+}
+
+// PlayerServiceGetBadgesV1 calls IPlayerService/GetBadges/v1.
+// Gets badges that are owned by a specific user.
+// Response is decoded into out, see Client.Do.
+func (c *Client) PlayerServiceGetBadgesV1(ctx context.Context, params *PlayerServiceGetBadgesV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("IPlayerService", "GetBadges", 1, "GET", params), out)
+}
+
+// PlayerServiceGetOwnedGamesV1Params are params of IPlayerService/GetOwnedGames/v1.
+type PlayerServiceGetOwnedGamesV1Params struct {
+	// The player we're asking about.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+	// True if we want additional details (name, icon) about each game.
+	IncludeAppInfo bool `json:"include_appinfo" steam:"include_appinfo"`
+	// Free games are excluded by default. If this is set, free games the user has played will be returned.
+	IncludePlayedFreeGames bool `json:"include_played_free_games" steam:"include_played_free_games"`
+	// If set, restricts result set to the passed in apps.
+	AppIDsFilter uint32 `json:"appids_filter" steam:"appids_filter"`
+	// Some games are in the free sub, which are excluded by default.
+	IncludeFreeSub bool `json:"include_free_sub" steam:"include_free_sub"`
+	// If set, skip unvetted store apps.
+	SkipUnvettedApps bool `json:"skip_unvetted_apps,omitempty" steam:"skip_unvetted_apps,optional"`
+	// Will return appinfo in this language.
+	Language string `json:"language" steam:"language"`
+	// True if we want even more details (capsule, sortas, and capabilities) about each game. include_appinfo must also be true.
+	IncludeExtendedAppInfo bool `json:"include_extended_appinfo" steam:"include_extended_appinfo"`
+}
+
+// PlayerServiceGetOwnedGamesV1 calls IPlayerService/GetOwnedGames/v1.
+// Return a list of games owned by the player.
+// Response is decoded into out, see Client.Do.
+func (c *Client) PlayerServiceGetOwnedGamesV1(ctx context.Context, params *PlayerServiceGetOwnedGamesV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("IPlayerService", "GetOwnedGames", 1, "GET", params), out)
+}
+
+// PlayerServiceGetRecentlyPlayedGamesV1Params are params of IPlayerService/GetRecentlyPlayedGames/v1.
+type PlayerServiceGetRecentlyPlayedGamesV1Params struct {
+	// The player we're asking about.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+	// The number of games to return (0/unset: all).
+	Count uint32 `json:"count" steam:"count"`
+}
+
+// PlayerServiceGetRecentlyPlayedGamesV1 calls IPlayerService/GetRecentlyPlayedGames/v1.
+// Gets information about a player's recently played games.
+// Response is decoded into out, see Client.Do.
+func (c *Client) PlayerServiceGetRecentlyPlayedGamesV1(ctx context.Context, params *PlayerServiceGetRecentlyPlayedGamesV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("IPlayerService", "GetRecentlyPlayedGames", 1, "GET", params), out)
+}
+
+// PlayerServiceGetSteamLevelV1Params are params of IPlayerService/GetSteamLevel/v1.
+type PlayerServiceGetSteamLevelV1Params struct {
+	// The player we're asking about.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+}
+
+// PlayerServiceGetSteamLevelV1 calls IPlayerService/GetSteamLevel/v1.
+// Returns the Steam Level of a user.
+// Response is decoded into out, see Client.Do.
+func (c *Client) PlayerServiceGetSteamLevelV1(ctx context.Context, params *PlayerServiceGetSteamLevelV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("IPlayerService", "GetSteamLevel", 1, "GET", params), out)
+}
+
+// SteamAppsGetAppListV2 calls ISteamApps/GetAppList/v2.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamAppsGetAppListV2(ctx context.Context, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamApps", "GetAppList", 2, "GET", nil), out)
+}
+
+// SteamAppsGetServersAtAddressV1Params are params of ISteamApps/GetServersAtAddress/v1.
+type SteamAppsGetServersAtAddressV1Params struct {
+	// IP or IP:queryport to list.
+	Addr string `json:"addr" steam:"addr"`
+}
+
+// SteamAppsGetServersAtAddressV1 calls ISteamApps/GetServersAtAddress/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamAppsGetServersAtAddressV1(ctx context.Context, params *SteamAppsGetServersAtAddressV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamApps", "GetServersAtAddress", 1, "GET", params), out)
+}
+
+// SteamAppsUpToDateCheckV1Params are params of ISteamApps/UpToDateCheck/v1.
+type SteamAppsUpToDateCheckV1Params struct {
+	// AppID of game.
+	AppID uint32 `json:"appid" steam:"appid"`
+	// The installed version of the game.
+	Version uint32 `json:"version" steam:"version"`
+}
+
+// SteamAppsUpToDateCheckV1 calls ISteamApps/UpToDateCheck/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamAppsUpToDateCheckV1(ctx context.Context, params *SteamAppsUpToDateCheckV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamApps", "UpToDateCheck", 1, "GET", params), out)
+}
+
+// SteamNewsGetNewsForAppV2Params are params of ISteamNews/GetNewsForApp/v2.
+type SteamNewsGetNewsForAppV2Params struct {
+	// AppID to retrieve news for.
+	AppID uint32 `json:"appid" steam:"appid"`
+	// Maximum length for the content to return, if this is 0 the full content is returned, if it's less then a blurb is generated to fit.
+	MaxLength uint32 `json:"maxlength,omitempty" steam:"maxlength,optional"`
+	// Retrieve posts earlier than this date (unix epoch timestamp).
+	EndDate uint32 `json:"enddate,omitempty" steam:"enddate,optional"`
+	// # of posts to retrieve (default 20).
+	Count uint32 `json:"count,omitempty" steam:"count,optional"`
+	// Comma-separated list of feed names to return news for.
+	Feeds string `json:"feeds,omitempty" steam:"feeds,optional"`
+	// Comma-separated list of tags to filter by (e.g. 'patchnodes').
+	Tags string `json:"tags,omitempty" steam:"tags,optional"`
+}
+
+// SteamNewsGetNewsForAppV2 calls ISteamNews/GetNewsForApp/v2.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamNewsGetNewsForAppV2(ctx context.Context, params *SteamNewsGetNewsForAppV2Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamNews", "GetNewsForApp", 2, "GET", params), out)
+}
+
+// SteamRemoteStorageGetCollectionDetailsV1Params are params of ISteamRemoteStorage/GetCollectionDetails/v1.
+type SteamRemoteStorageGetCollectionDetailsV1Params struct {
+	// Number of collections being requested.
+	CollectionCount uint32 `json:"collectioncount" steam:"collectioncount"`
+	// Collection ids to get the details for.
+	PublishedFileIDs []uint64 `json:"publishedfileids" steam:"publishedfileids,array"`
+}
+
+// SteamRemoteStorageGetCollectionDetailsV1 calls ISteamRemoteStorage/GetCollectionDetails/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamRemoteStorageGetCollectionDetailsV1(ctx context.Context, params *SteamRemoteStorageGetCollectionDetailsV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamRemoteStorage", "GetCollectionDetails", 1, "POST", params), out)
+}
+
+// SteamRemoteStorageGetPublishedFileDetailsV1Params are params of ISteamRemoteStorage/GetPublishedFileDetails/v1.
+type SteamRemoteStorageGetPublishedFileDetailsV1Params struct {
+	// Number of items being requested.
+	ItemCount uint32 `json:"itemcount" steam:"itemcount"`
+	// Published file id to look up.
+	PublishedFileIDs []uint64 `json:"publishedfileids" steam:"publishedfileids,array"`
+}
+
+// SteamRemoteStorageGetPublishedFileDetailsV1 calls ISteamRemoteStorage/GetPublishedFileDetails/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamRemoteStorageGetPublishedFileDetailsV1(ctx context.Context, params *SteamRemoteStorageGetPublishedFileDetailsV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamRemoteStorage", "GetPublishedFileDetails", 1, "POST", params), out)
+}
+
+// SteamUserGetFriendListV1Params are params of ISteamUser/GetFriendList/v1.
+type SteamUserGetFriendListV1Params struct {
+	// SteamID of user.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+	// Relationship type (ex: friend).
+	Relationship string `json:"relationship,omitempty" steam:"relationship,optional"`
+}
+
+// SteamUserGetFriendListV1 calls ISteamUser/GetFriendList/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserGetFriendListV1(ctx context.Context, params *SteamUserGetFriendListV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUser", "GetFriendList", 1, "GET", params), out)
+}
+
+// SteamUserGetPlayerBansV1Params are params of ISteamUser/GetPlayerBans/v1.
+type SteamUserGetPlayerBansV1Params struct {
+	// Comma-delimited list of SteamIDs.
+	SteamIDs string `json:"steamids" steam:"steamids"`
+}
+
+// SteamUserGetPlayerBansV1 calls ISteamUser/GetPlayerBans/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserGetPlayerBansV1(ctx context.Context, params *SteamUserGetPlayerBansV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUser", "GetPlayerBans", 1, "GET", params), out)
+}
+
+// SteamUserGetPlayerSummariesV2Params are params of ISteamUser/GetPlayerSummaries/v2.
+type SteamUserGetPlayerSummariesV2Params struct {
+	// Comma-delimited list of SteamIDs (max: 100).
+	SteamIDs string `json:"steamids" steam:"steamids"`
+}
+
+// SteamUserGetPlayerSummariesV2 calls ISteamUser/GetPlayerSummaries/v2.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserGetPlayerSummariesV2(ctx context.Context, params *SteamUserGetPlayerSummariesV2Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUser", "GetPlayerSummaries", 2, "GET", params), out)
+}
+
+// SteamUserGetUserGroupListV1Params are params of ISteamUser/GetUserGroupList/v1.
+type SteamUserGetUserGroupListV1Params struct {
+	// SteamID of user.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+}
+
+// SteamUserGetUserGroupListV1 calls ISteamUser/GetUserGroupList/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserGetUserGroupListV1(ctx context.Context, params *SteamUserGetUserGroupListV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUser", "GetUserGroupList", 1, "GET", params), out)
+}
+
+// SteamUserResolveVanityURLV1Params are params of ISteamUser/ResolveVanityURL/v1.
+type SteamUserResolveVanityURLV1Params struct {
+	// The vanity URL to get a SteamID for.
+	VanityURL string `json:"vanityurl" steam:"vanityurl"`
+	// The type of vanity URL. 1 (default): Individual profile, 2: Group, 3: Official game group.
+	URLType int32 `json:"url_type,omitempty" steam:"url_type,optional"`
+}
+
+// SteamUserResolveVanityURLV1 calls ISteamUser/ResolveVanityURL/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserResolveVanityURLV1(ctx context.Context, params *SteamUserResolveVanityURLV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUser", "ResolveVanityURL", 1, "GET", params), out)
+}
+
+// SteamUserStatsGetGlobalAchievementPercentagesForAppV2Params are params of ISteamUserStats/GetGlobalAchievementPercentagesForApp/v2.
+type SteamUserStatsGetGlobalAchievementPercentagesForAppV2Params struct {
+	// GameID to retrieve the achievement percentages for.
+	GameID uint64 `json:"gameid" steam:"gameid"`
+}
+
+// SteamUserStatsGetGlobalAchievementPercentagesForAppV2 calls ISteamUserStats/GetGlobalAchievementPercentagesForApp/v2.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserStatsGetGlobalAchievementPercentagesForAppV2(ctx context.Context, params *SteamUserStatsGetGlobalAchievementPercentagesForAppV2Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUserStats", "GetGlobalAchievementPercentagesForApp", 2, "GET", params), out)
+}
+
+// SteamUserStatsGetNumberOfCurrentPlayersV1Params are params of ISteamUserStats/GetNumberOfCurrentPlayers/v1.
+type SteamUserStatsGetNumberOfCurrentPlayersV1Params struct {
+	// AppID that we're getting user count for.
+	AppID uint32 `json:"appid" steam:"appid"`
+}
+
+// SteamUserStatsGetNumberOfCurrentPlayersV1 calls ISteamUserStats/GetNumberOfCurrentPlayers/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserStatsGetNumberOfCurrentPlayersV1(ctx context.Context, params *SteamUserStatsGetNumberOfCurrentPlayersV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUserStats", "GetNumberOfCurrentPlayers", 1, "GET", params), out)
+}
+
+// SteamUserStatsGetPlayerAchievementsV1Params are params of ISteamUserStats/GetPlayerAchievements/v1.
+type SteamUserStatsGetPlayerAchievementsV1Params struct {
+	// SteamID of user.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+	// AppID to get achievements for.
+	AppID uint32 `json:"appid" steam:"appid"`
+	// Language to return strings for.
+	Language string `json:"l,omitempty" steam:"l,optional"`
+}
+
+// SteamUserStatsGetPlayerAchievementsV1 calls ISteamUserStats/GetPlayerAchievements/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserStatsGetPlayerAchievementsV1(ctx context.Context, params *SteamUserStatsGetPlayerAchievementsV1Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUserStats", "GetPlayerAchievements", 1, "GET", params), out)
+}
+
+// SteamUserStatsGetSchemaForGameV2Params are params of ISteamUserStats/GetSchemaForGame/v2.
+type SteamUserStatsGetSchemaForGameV2Params struct {
+	// Appid of game.
+	AppID uint32 `json:"appid" steam:"appid"`
+	// Localized language to return (english, french, etc.).
+	Language string `json:"l,omitempty" steam:"l,optional"`
+}
+
+// SteamUserStatsGetSchemaForGameV2 calls ISteamUserStats/GetSchemaForGame/v2.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserStatsGetSchemaForGameV2(ctx context.Context, params *SteamUserStatsGetSchemaForGameV2Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUserStats", "GetSchemaForGame", 2, "GET", params), out)
+}
+
+// SteamUserStatsGetUserStatsForGameV2Params are params of ISteamUserStats/GetUserStatsForGame/v2.
+type SteamUserStatsGetUserStatsForGameV2Params struct {
+	// SteamID of user.
+	SteamID uint64 `json:"steamid" steam:"steamid"`
+	// Appid of game.
+	AppID uint32 `json:"appid" steam:"appid"`
+}
+
+// SteamUserStatsGetUserStatsForGameV2 calls ISteamUserStats/GetUserStatsForGame/v2.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamUserStatsGetUserStatsForGameV2(ctx context.Context, params *SteamUserStatsGetUserStatsForGameV2Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamUserStats", "GetUserStatsForGame", 2, "GET", params), out)
+}
+
+// SteamWebAPIUtilGetServerInfoV1 calls ISteamWebAPIUtil/GetServerInfo/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamWebAPIUtilGetServerInfoV1(ctx context.Context, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamWebAPIUtil", "GetServerInfo", 1, "GET", nil), out)
+}
+
+// SteamWebAPIUtilGetSupportedAPIListV1 calls ISteamWebAPIUtil/GetSupportedAPIList/v1.
+// Response is decoded into out, see Client.Do.
+func (c *Client) SteamWebAPIUtilGetSupportedAPIListV1(ctx context.Context, out any) error {
+	return c.Do(ctx, newGeneratedRequest("ISteamWebAPIUtil", "GetSupportedAPIList", 1, "GET", nil), out)
+}
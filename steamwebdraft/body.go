@@ -0,0 +1,145 @@
+package steamweb
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// AcceptEncoding lists content encodings requested unless
+// Transport.DisableCompression is set.
+const AcceptEncoding = "gzip, deflate, br"
+
+var (
+	ErrResponseTooLarge    = errors.New("response is too large")
+	ErrTruncatedResponse   = errors.New("response is truncated")
+	ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+)
+
+// ResponseTooLargeError is returned when decoded response body exceeds
+// the limit. It matches ErrResponseTooLarge with errors.Is.
+type ResponseTooLargeError struct {
+	// Limit is the exceeded limit in bytes.
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s: more than %d bytes", ErrResponseTooLarge, e.Limit)
+}
+
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// decodeContent returns reader of body decoded according to Content-Encoding.
+func decodeContent(body io.Reader, encoding string) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		return newDeflateReader(body)
+	case "br":
+		return brotli.NewReader(body), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+// newDeflateReader reads zlib wrapped deflate data as specified by HTTP
+// and raw deflate data sent by some servers instead.
+func newDeflateReader(body io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(body)
+
+	header, err := buffered.Peek(2) //nolint:mnd // Size of zlib header.
+	if err != nil {
+		return nil, err
+	}
+
+	// Compression method 8 and header checksum of zlib stream, see RFC 1950.
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
+
+// countingReader counts bytes read from the response body.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// limitedBuffer keeps the first limit bytes written to it.
+type limitedBuffer struct {
+	buf   []byte
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if free := b.limit - len(b.buf); free > 0 {
+		b.buf = append(b.buf, p[:min(free, len(p))]...)
+	}
+
+	return len(p), nil
+}
+
+// maxBytesReader fails with ErrResponseTooLarge when more than limit bytes
+// are read. Zero or negative limit means no limit.
+type maxBytesReader struct {
+	r     io.Reader
+	n     int64
+	limit int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if m.limit <= 0 {
+		return m.r.Read(p)
+	}
+
+	if m.n > m.limit {
+		return 0, m.err()
+	}
+
+	// Read one byte over the limit to tell whether the body ends at it.
+	if left := m.limit - m.n + 1; int64(len(p)) > left {
+		p = p[:left]
+	}
+
+	n, err := m.r.Read(p)
+	m.n += int64(n)
+
+	if m.n > m.limit {
+		return n - int(m.n-m.limit), m.err()
+	}
+
+	return n, err
+}
+
+func (m *maxBytesReader) err() error {
+	return &ResponseTooLargeError{Limit: m.limit}
+}
+
+// truncatedError marks unexpected end of the body with ErrTruncatedResponse.
+func truncatedError(err error) error {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %w", ErrTruncatedResponse, err)
+	}
+
+	return err
+}
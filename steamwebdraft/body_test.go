@@ -0,0 +1,221 @@
+package steamweb
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statsRecorder struct {
+	stats []RequestStats
+}
+
+func (r *statsRecorder) RequestStarted(ctx context.Context, _ RequestInfo) context.Context {
+	return ctx
+}
+
+func (r *statsRecorder) RequestFinished(_ context.Context, _ RequestInfo, stats RequestStats) {
+	r.stats = append(r.stats, stats)
+}
+
+func compress(t *testing.T, encoding, body string) []byte {
+	t.Helper()
+
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw deflate":
+		var err error
+
+		w, err = flate.NewWriter(&buf, flate.DefaultCompression)
+		require.NoError(t, err)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	default:
+		return []byte(body)
+	}
+
+	_, err := io.WriteString(w, body)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestClient_Decompression(t *testing.T) {
+	body := `{"players":[` + strings.Repeat(`{"SteamId":"76561197960435530","EconomyBan":"none"},`, 100) + `{}]}`
+
+	for _, encoding := range []string{"", "gzip", "deflate", "raw deflate", "br"} {
+		t.Run(encoding, func(t *testing.T) {
+			compressed := compress(t, encoding, body)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, AcceptEncoding, r.Header.Get("Accept-Encoding"))
+
+				if encoding != "" {
+					w.Header().Set("Content-Encoding", strings.TrimPrefix(encoding, "raw "))
+				}
+
+				w.Write(compressed)
+			}))
+			defer ts.Close()
+
+			recorder := &statsRecorder{}
+			client := NewClient(newConfig(ts.URL), WithInstrumenter(recorder))
+
+			bans, err := client.GetPlayerBans("76561197960435530")
+			require.NoError(t, err)
+			assert.Len(t, bans, 101)
+
+			require.Len(t, recorder.stats, 1)
+			assert.Equal(t, int64(len(compressed)), recorder.stats[0].Bytes)
+			assert.Equal(t, int64(len(body)), recorder.stats[0].DecodedBytes)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// http.Transport asks for gzip on its own and decodes it transparently.
+			assert.NotContains(t, r.Header.Get("Accept-Encoding"), "br")
+			w.Write([]byte(body))
+		}))
+		defer ts.Close()
+
+		cfg := newConfig(ts.URL)
+		cfg.Transport.DisableCompression = true
+
+		_, err := NewClient(cfg).GetPlayerBans("1")
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		client := NewClient(newConfig("http://steam.test"), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			res := jsonResponse(req, body)
+			res.Header.Set("Content-Encoding", "zstd")
+
+			return res, nil
+		})))
+
+		_, err := client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrUnsupportedEncoding)
+		assert.Equal(t, ErrorClassResponse, ErrorClass(err))
+	})
+}
+
+func TestClient_MaxResponseSize(t *testing.T) {
+	body := `{"players":[{"SteamId":"76561197960435530"}]}`
+
+	for _, encoding := range []string{"", "gzip"} {
+		t.Run(fmt.Sprintf("encoding %q", encoding), func(t *testing.T) {
+			compressed := compress(t, encoding, body)
+
+			newClient := func(limit int64) *Client {
+				cfg := newConfig("http://steam.test")
+				cfg.MaxResponseSize = limit
+
+				return NewClient(cfg, WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					res := jsonResponse(req, string(compressed))
+					if encoding != "" {
+						res.Header.Set("Content-Encoding", encoding)
+					}
+
+					return res, nil
+				})))
+			}
+
+			_, err := newClient(int64(len(body))).GetPlayerBans("1")
+			require.NoError(t, err)
+
+			_, err = newClient(-1).GetPlayerBans("1")
+			require.NoError(t, err)
+
+			_, err = newClient(int64(len(body)) - 1).GetPlayerBans("1")
+			require.ErrorIs(t, err, ErrResponseTooLarge)
+
+			var tooLarge *ResponseTooLargeError
+			require.ErrorAs(t, err, &tooLarge)
+			assert.Equal(t, int64(len(body))-1, tooLarge.Limit)
+			assert.Equal(t, ErrorClassResponse, ErrorClass(err))
+		})
+	}
+}
+
+func TestClient_MaxResponseSize_DefaultLimit(t *testing.T) {
+	server := `{"addr":"127.0.0.1:16261","gameport":16262,"steamid":"90268762852129810",` +
+		`"name":"` + strings.Repeat("n", 63) + `","appid":108600,"gamedir":"zomboid","version":"41.78.16",` +
+		`"product":"zomboid","region":255,"players":32,"max_players":64,"bots":0,` +
+		`"map":"` + strings.Repeat("m", 255) + `","secure":true,"dedicated":true,"os":"l",` +
+		`"gametype":"` + strings.Repeat("t", 511) + `"}`
+
+	var body strings.Builder
+
+	body.WriteString(`{"response":{"servers":[`)
+
+	for i := range DefaultLimit {
+		if i > 0 {
+			body.WriteString(",")
+		}
+
+		body.WriteString(server)
+	}
+
+	body.WriteString(`]}}`)
+	require.Greater(t, body.Len(), 32<<20)
+
+	client := NewClient(newConfig("http://steam.test"), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, body.String()), nil
+	})))
+
+	servers, err := client.GetServerList(&GetServerListFilter{AppID: 108600})
+	require.NoError(t, err)
+	assert.Len(t, servers, DefaultLimit)
+}
+
+func TestClient_TruncatedResponse(t *testing.T) {
+	body := `{"players":[{"SteamId":"76561197960435530"}]}`
+
+	t.Run("content length", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.Write([]byte(body[:10]))
+		}))
+		defer ts.Close()
+
+		_, err := NewClient(newConfig(ts.URL)).GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrTruncatedResponse)
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		compressed := compress(t, "gzip", body)
+
+		client := NewClient(newConfig("http://steam.test"), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			res := jsonResponse(req, string(compressed[:len(compressed)-10]))
+			res.Header.Set("Content-Encoding", "gzip")
+
+			return res, nil
+		})))
+
+		_, err := client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrTruncatedResponse)
+	})
+}
@@ -0,0 +1,283 @@
+package steamweb
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultCacheTTL        = time.Minute
+	DefaultCacheMaxEntries = 1000
+	DefaultCacheMaxBytes   = 64 << 20
+)
+
+type (
+	// Cache stores response bodies of Steam Web API requests.
+	// Implementations must be safe for concurrent use.
+	Cache interface {
+		// Get returns entry stored for the key.
+		Get(key string) (CacheEntry, bool)
+		// Set stores entry for the key.
+		Set(key string, entry CacheEntry)
+	}
+
+	// CacheEntry is a cached response body.
+	CacheEntry struct {
+		// Body is a response body. It must not be modified.
+		Body []byte
+
+		// Expires is a time after which the entry is stale.
+		Expires time.Time
+
+		// StaleUntil is a time until which the stale entry may be returned
+		// while it is refreshed in background.
+		StaleUntil time.Time
+	}
+
+	// CacheStats contains counters of the response cache.
+	CacheStats struct {
+		// Hits is a number of requests served with fresh entries.
+		Hits uint64 `json:"hits"`
+
+		// StaleHits is a number of requests served with stale entries
+		// while they were refreshed in background.
+		StaleHits uint64 `json:"stale_hits"`
+
+		// Misses is a number of cacheable requests sent to Steam.
+		Misses uint64 `json:"misses"`
+	}
+)
+
+// responseCache applies Config.Cache policy on top of Cache.
+type responseCache struct {
+	cache   Cache
+	config  *CacheConfig
+	baseURL string
+	now     func() time.Time
+
+	hits      atomic.Uint64
+	staleHits atomic.Uint64
+	misses    atomic.Uint64
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+}
+
+func newResponseCache(cache Cache, cfg *CacheConfig, baseURL string) *responseCache {
+	return &responseCache{
+		cache:      cache,
+		config:     cfg,
+		baseURL:    baseURL,
+		now:        time.Now,
+		refreshing: make(map[string]bool),
+	}
+}
+
+// ttl returns time to live for responses of the request, zero means
+// that the request is not cached.
+func (rc *responseCache) ttl(req *Request) time.Duration {
+	if req.httpMethod() != http.MethodGet {
+		return 0
+	}
+
+	if ttl, ok := rc.config.TTLs[req.Interface+"/"+req.Method]; ok {
+		return ttl
+	}
+
+	return rc.config.TTL
+}
+
+// fetch returns cached response body for the request or calls send and caches its result.
+func (rc *responseCache) fetch(
+	ctx context.Context, req *Request, send func(context.Context, *Request) ([]byte, error),
+) ([]byte, error) {
+	ttl := rc.ttl(req)
+	if ttl <= 0 {
+		return send(ctx, req)
+	}
+
+	key, err := requestKey(rc.baseURL, req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := rc.now()
+
+	if entry, ok := rc.cache.Get(key); ok {
+		if now.Before(entry.Expires) {
+			rc.hits.Add(1)
+
+			return entry.Body, nil
+		}
+
+		if now.Before(entry.StaleUntil) {
+			rc.staleHits.Add(1)
+			rc.refresh(key, req, ttl, send)
+
+			return entry.Body, nil
+		}
+	}
+
+	rc.misses.Add(1)
+
+	return rc.store(ctx, key, req, ttl, send)
+}
+
+// refresh updates stale entry in background once per key.
+func (rc *responseCache) refresh(
+	key string, req *Request, ttl time.Duration, send func(context.Context, *Request) ([]byte, error),
+) {
+	rc.mu.Lock()
+	if rc.refreshing[key] {
+		rc.mu.Unlock()
+
+		return
+	}
+
+	rc.refreshing[key] = true
+	rc.mu.Unlock()
+
+	go func() {
+		defer func() {
+			rc.mu.Lock()
+			delete(rc.refreshing, key)
+			rc.mu.Unlock()
+		}()
+
+		// The caller already has the response, refreshing must outlive it
+		// and must not be reported as a part of its call or trace span.
+		_, _ = rc.store(context.Background(), key, req, ttl, send)
+	}()
+}
+
+func (rc *responseCache) store(
+	ctx context.Context, key string, req *Request, ttl time.Duration, send func(context.Context, *Request) ([]byte, error),
+) ([]byte, error) {
+	body, err := send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := rc.now().Add(ttl)
+
+	rc.cache.Set(key, CacheEntry{
+		Body:       body,
+		Expires:    expires,
+		StaleUntil: expires.Add(rc.config.StaleWhileRevalidate),
+	})
+
+	return body, nil
+}
+
+func (rc *responseCache) stats() CacheStats {
+	return CacheStats{
+		Hits:      rc.hits.Load(),
+		StaleHits: rc.staleHits.Load(),
+		Misses:    rc.misses.Load(),
+	}
+}
+
+// requestKey returns normalized representation of the request sent to
+// baseURL. It never contains the api key, which is added to params only
+// when request is sent.
+func requestKey(baseURL string, req *Request) (string, error) {
+	values, err := req.values()
+	if err != nil {
+		return "", err
+	}
+
+	// Encode sorts params by name.
+	return req.httpMethod() + " " + strings.TrimSuffix(baseURL, "/") + req.Path() + "?" + values.Encode(), nil
+}
+
+// LRUCache is in-memory Cache evicting least recently used entries
+// when it holds more than maxEntries entries or maxBytes of bodies.
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	entries    *list.List
+	index      map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCache creates LRUCache. Zero or negative limit means no limit.
+func NewLRUCache(maxEntries int, maxBytes int64) *LRUCache {
+	return &LRUCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    list.New(),
+		index:      make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.index[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	c.entries.MoveToFront(element)
+
+	item, _ := element.Value.(*lruItem)
+
+	return item.entry, true
+}
+
+// Set implements Cache. Entries larger than maxBytes are not stored.
+func (c *LRUCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.index[key]; ok {
+		c.remove(element)
+	}
+
+	size := int64(len(entry.Body))
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return
+	}
+
+	c.index[key] = c.entries.PushFront(&lruItem{key: key, entry: entry})
+	c.bytes += size
+
+	for (c.maxEntries > 0 && c.entries.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.entries.Back())
+	}
+}
+
+// Len returns a number of cached entries.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.entries.Len()
+}
+
+// Size returns total size of cached bodies in bytes.
+func (c *LRUCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.bytes
+}
+
+func (c *LRUCache) remove(element *list.Element) {
+	item, _ := c.entries.Remove(element).(*lruItem)
+	delete(c.index, item.key)
+	c.bytes -= int64(len(item.entry.Body))
+}
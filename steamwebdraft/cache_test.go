@@ -0,0 +1,208 @@
+package steamweb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Run("max entries", func(t *testing.T) {
+		cache := NewLRUCache(2, 0)
+		cache.Set("a", CacheEntry{Body: []byte("a")})
+		cache.Set("b", CacheEntry{Body: []byte("b")})
+
+		_, ok := cache.Get("a")
+		require.True(t, ok)
+
+		cache.Set("c", CacheEntry{Body: []byte("c")})
+
+		_, ok = cache.Get("b")
+		assert.False(t, ok, "least recently used entry must be evicted")
+
+		_, ok = cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("max bytes", func(t *testing.T) {
+		cache := NewLRUCache(0, 5)
+		cache.Set("a", CacheEntry{Body: []byte("aaa")})
+		cache.Set("b", CacheEntry{Body: []byte("bbb")})
+
+		_, ok := cache.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, int64(3), cache.Size())
+
+		cache.Set("c", CacheEntry{Body: []byte("cccccc")})
+
+		_, ok = cache.Get("c")
+		assert.False(t, ok, "entry larger than the limit must not be stored")
+
+		cache.Set("b", CacheEntry{Body: []byte("b")})
+		assert.Equal(t, int64(1), cache.Size())
+	})
+}
+
+func TestClient_Cache(t *testing.T) {
+	var calls atomic.Int32
+
+	refreshed := make(chan struct{}, 1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+
+		fmt.Fprintf(w, `{"players":[{"SteamId":"%d"}]}`, n)
+
+		if r.URL.Query().Get("steamids") == "refresh" && n > 1 {
+			refreshed <- struct{}{}
+		}
+	}))
+	defer ts.Close()
+
+	cfg := newConfig(ts.URL)
+	cfg.Keys = []string{"second key"}
+	cfg.Cache = CacheConfig{
+		Enabled:              true,
+		TTL:                  time.Minute,
+		TTLs:                 map[string]time.Duration{"ISteamUser/GetPlayerSummaries": 0},
+		StaleWhileRevalidate: time.Minute,
+	}
+
+	client := NewClient(cfg)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.cache.now = func() time.Time { return now }
+
+	bans := func(steamID string) string {
+		t.Helper()
+
+		got, err := client.GetPlayerBans(steamID)
+		require.NoError(t, err)
+
+		return got[0].SteamID
+	}
+
+	t.Run("hit ignores api key", func(t *testing.T) {
+		assert.Equal(t, "1", bans("1"))
+		assert.Equal(t, "1", bans("1"))
+		assert.Equal(t, "2", bans("2"))
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 2}, client.CacheStats())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(2*time.Minute + time.Second)
+
+		assert.Equal(t, "3", bans("1"))
+	})
+
+	t.Run("stale while revalidate", func(t *testing.T) {
+		assert.Equal(t, "4", bans("refresh"))
+
+		now = now.Add(time.Minute + time.Second)
+
+		assert.Equal(t, "4", bans("refresh"), "stale response must be returned")
+
+		select {
+		case <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("stale response was not refreshed")
+		}
+
+		require.Eventually(t, func() bool {
+			return bans("refresh") == "5"
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("not cached", func(t *testing.T) {
+		before := calls.Load()
+
+		require.NoError(t, client.Call(context.Background(), "ISteamUser", "GetPlayerSummaries", 2, nil, nil))
+		require.NoError(t, client.Call(context.Background(), "ISteamUser", "GetPlayerSummaries", 2, nil, nil))
+		require.NoError(t, client.Do(context.Background(), &Request{Interface: "ISteamUser", Method: "GetPlayerBans", Version: 1, HTTPMethod: http.MethodPost}, nil))
+
+		assert.Equal(t, before+3, calls.Load())
+	})
+}
+
+func TestClient_Cache_SharedBetweenURLs(t *testing.T) {
+	cache := NewLRUCache(0, 0)
+
+	newClient := func(uri, steamID string) *Client {
+		cfg := newConfig(uri)
+		cfg.Cache = CacheConfig{Enabled: true, TTL: time.Minute}
+
+		return NewClient(cfg, WithCache(cache), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, `{"players":[{"SteamId":"`+steamID+`"}]}`), nil
+		})))
+	}
+
+	first := newClient("http://first.test", "1")
+	second := newClient("http://second.test/", "2")
+
+	for _, tt := range []struct {
+		client *Client
+		want   string
+	}{{first, "1"}, {second, "2"}, {first, "1"}} {
+		got, err := tt.client.GetPlayerBans("1")
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got[0].SteamID)
+	}
+
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestClient_Cache_RefreshContext(t *testing.T) {
+	type ctxKey struct{}
+
+	var calls atomic.Int32
+
+	type refresh struct {
+		value any
+		err   error
+	}
+
+	refreshed := make(chan refresh, 1)
+
+	cfg := newConfig("http://steam.test")
+	cfg.Cache = CacheConfig{Enabled: true, TTL: time.Minute, StaleWhileRevalidate: time.Minute}
+
+	client := NewClient(cfg, WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) > 1 {
+			ctx := req.Context()
+			refreshed <- refresh{value: ctx.Value(ctxKey{}), err: ctx.Err()}
+		}
+
+		return jsonResponse(req, `{"players":[{"SteamId":"1"}]}`), nil
+	})))
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.cache.now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "caller"))
+	defer cancel()
+
+	_, err := client.GetPlayerBansContext(ctx, "1")
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute + time.Second)
+
+	_, err = client.GetPlayerBansContext(ctx, "1")
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case got := <-refreshed:
+		assert.Nil(t, got.value, "refresh must not carry values of the caller")
+		require.NoError(t, got.err)
+	case <-time.After(time.Second):
+		t.Fatal("stale response was not refreshed")
+	}
+}
@@ -0,0 +1,234 @@
+package steamweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// InputJSONParam is a param holding JSON encoded input of service interfaces.
+const InputJSONParam = "input_json"
+
+// Request describes a call of Steam Web API method.
+type Request struct {
+	// Interface is an API interface name, e.g. ISteamUser.
+	Interface string
+
+	// Method is an API method name, e.g. GetPlayerBans.
+	Method string
+
+	// Version is a method version, e.g. 1.
+	Version int
+
+	// HTTPMethod is http.MethodGet or http.MethodPost.
+	//
+	// The default is GET.
+	HTTPMethod string
+
+	// Params are sent in the query string for GET requests
+	// and in the form encoded body for POST requests.
+	Params url.Values
+
+	// InputJSON is encoded to JSON and sent as input_json param.
+	// Service interfaces (I*Service) accept their params this way.
+	InputJSON any
+}
+
+// Path returns URL path of the method, e.g. /ISteamUser/GetPlayerBans/v1.
+func (r *Request) Path() string {
+	return "/" + url.PathEscape(r.Interface) + "/" + url.PathEscape(r.Method) + "/v" + strconv.Itoa(r.Version)
+}
+
+// Validate checks that method is fully defined.
+func (r *Request) Validate() error {
+	if r.Interface == "" {
+		return fmt.Errorf("%w: %s", ErrRequiredParam, "interface")
+	}
+
+	if r.Method == "" {
+		return fmt.Errorf("%w: %s", ErrRequiredParam, "method")
+	}
+
+	if r.Version <= 0 {
+		return fmt.Errorf("%w: %s", ErrRequiredParam, "version")
+	}
+
+	// Path separators would address another method.
+	if i := strings.IndexAny(r.Interface, "/?#"); i >= 0 {
+		return fmt.Errorf("%w: %s: forbidden character %q", ErrInvalidParam, "interface", r.Interface[i])
+	}
+
+	if i := strings.IndexAny(r.Method, "/?#"); i >= 0 {
+		return fmt.Errorf("%w: %s: forbidden character %q", ErrInvalidParam, "method", r.Method[i])
+	}
+
+	switch r.HTTPMethod {
+	case "", http.MethodGet, http.MethodPost:
+	default:
+		return fmt.Errorf("%w: unsupported http method %q", ErrInvalidParam, r.HTTPMethod)
+	}
+
+	return nil
+}
+
+func (r *Request) httpMethod() string {
+	if r.HTTPMethod == "" {
+		return http.MethodGet
+	}
+
+	return r.HTTPMethod
+}
+
+// values returns a copy of params with encoded input_json.
+func (r *Request) values() (url.Values, error) {
+	values := make(url.Values, len(r.Params)+1)
+	for name, list := range r.Params {
+		values[name] = append([]string(nil), list...)
+	}
+
+	if r.InputJSON != nil {
+		input, err := json.Marshal(r.InputJSON)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidParam, InputJSONParam, err)
+		}
+
+		values.Set(InputJSONParam, string(input))
+	}
+
+	return values, nil
+}
+
+// newHTTPRequest builds http.Request for the method call with the given api key.
+func (c *Client) newHTTPRequest(ctx context.Context, r *Request, key string) (*http.Request, error) {
+	values, err := r.values()
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.config.KeyInHeader && key != "" {
+		values.Set(KeyParam, key)
+	}
+
+	uri := strings.TrimSuffix(c.config.URL, "/") + r.Path()
+
+	var body io.Reader = http.NoBody
+
+	if r.httpMethod() == http.MethodPost {
+		body = strings.NewReader(values.Encode())
+	} else if len(values) != 0 {
+		uri += "?" + values.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.httpMethod(), uri, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.httpMethod() == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if !c.config.Transport.DisableCompression {
+		req.Header.Set("Accept-Encoding", AcceptEncoding)
+	}
+
+	if c.config.KeyInHeader && key != "" {
+		req.Header.Set(KeyHeader, key)
+	}
+
+	return req, nil
+}
+
+// Call calls Steam Web API method with GET request and decodes JSON response
+// into out. It can be used to reach methods not wrapped by Client, e.g.:
+//
+//	var out struct{ Response struct{ Players []json.RawMessage } }
+//	err := client.Call(ctx, "ISteamUser", "GetPlayerSummaries", 2, url.Values{"steamids": {id}}, &out)
+func (c *Client) Call(ctx context.Context, iface, method string, version int, params url.Values, out any) error {
+	return c.Do(ctx, &Request{Interface: iface, Method: method, Version: version, Params: params}, out)
+}
+
+// Do sends request and decodes JSON response into out. Raw response body
+// is stored when out is *[]byte or *json.RawMessage, nil out discards it.
+// Disabled client returns nil without sending anything.
+func (c *Client) Do(ctx context.Context, req *Request, out any) error {
+	return c.call(ctx, req, func(body []byte) (int, error) {
+		return -1, decodeBody(body, out)
+	})
+}
+
+// call sends request and passes response body to decode, which returns
+// a number of items reported to CallInstrumenter.
+func (c *Client) call(ctx context.Context, req *Request, decode func(body []byte) (int, error)) error {
+	if c.config.Disabled {
+		return nil
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	return c.instrumentCall(ctx, req, func(ctx context.Context) (int, error) {
+		body, err := c.fetch(ctx, req)
+		if err != nil {
+			return -1, err
+		}
+
+		return decode(body)
+	})
+}
+
+// instrumentCall reports call of fn to CallInstrumenter.
+func (c *Client) instrumentCall(
+	ctx context.Context, req *Request, fn func(ctx context.Context) (items int, err error),
+) (err error) {
+	if len(c.instrumenters) == 0 {
+		_, err = fn(ctx)
+
+		return err
+	}
+
+	info := CallInfo{
+		Interface:  req.Interface,
+		Method:     req.Method,
+		Version:    req.Version,
+		HTTPMethod: req.httpMethod(),
+	}
+
+	state := &callState{}
+	ctx = c.instrumenters.CallStarted(context.WithValue(ctx, callStateKey{}, state), info)
+	items := -1
+
+	defer func() {
+		c.instrumenters.CallFinished(ctx, info, CallStats{
+			StatusCode: int(state.status.Load()),
+			Attempts:   int(state.attempts.Load()),
+			Items:      items,
+			Err:        err,
+		})
+	}()
+
+	items, err = fn(ctx)
+
+	return err
+}
+
+func decodeBody(body []byte, out any) error {
+	switch target := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		// Body may be shared with the cache.
+		*target = bytes.Clone(body)
+
+		return nil
+	default:
+		return json.Unmarshal(body, out)
+	}
+}
@@ -0,0 +1,117 @@
+package steamweb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Call(t *testing.T) {
+	var got *http.Request
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		got = r
+
+		w.Write([]byte(`{"response":{"players":[{"steamid":"7656119","personaname":"gordon"}]}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(newConfig(ts.URL))
+
+	t.Run("get", func(t *testing.T) {
+		var out struct {
+			Response struct {
+				Players []struct {
+					SteamID     string `json:"steamid"`
+					PersonaName string `json:"personaname"`
+				} `json:"players"`
+			} `json:"response"`
+		}
+
+		err := client.Call(context.Background(), "ISteamUser", "GetPlayerSummaries", 2, url.Values{"steamids": {"7656119&x=y"}}, &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodGet, got.Method)
+		assert.Equal(t, "/ISteamUser/GetPlayerSummaries/v2", got.URL.Path)
+		assert.Equal(t, "7656119&x=y", got.URL.Query().Get("steamids"))
+		assert.Equal(t, testKey, got.URL.Query().Get(KeyParam))
+
+		if assert.Len(t, out.Response.Players, 1) {
+			assert.Equal(t, "gordon", out.Response.Players[0].PersonaName)
+		}
+	})
+
+	t.Run("post input_json", func(t *testing.T) {
+		var raw json.RawMessage
+
+		err := client.Do(context.Background(), &Request{
+			Interface:  "IPlayerService",
+			Method:     "GetOwnedGames",
+			Version:    1,
+			HTTPMethod: http.MethodPost,
+			InputJSON:  map[string]any{"steamid": "7656119", "appids_filter": []int{108600}},
+		}, &raw)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, got.Method)
+		assert.Empty(t, got.URL.RawQuery)
+		assert.Equal(t, testKey, got.PostForm.Get(KeyParam))
+		assert.JSONEq(t, `{"steamid":"7656119","appids_filter":[108600]}`, got.PostForm.Get(InputJSONParam))
+		assert.Contains(t, string(raw), "gordon")
+	})
+
+	t.Run("raw body", func(t *testing.T) {
+		var raw []byte
+
+		require.NoError(t, client.Call(context.Background(), "ISteamUser", "GetPlayerSummaries", 2, nil, &raw))
+		assert.Contains(t, string(raw), "gordon")
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		err := client.Call(context.Background(), "ISteamUser", "", 2, nil, nil)
+		require.ErrorIs(t, err, ErrRequiredParam)
+
+		err = client.Do(context.Background(), &Request{Interface: "ISteamUser", Method: "GetPlayerSummaries", Version: 2, HTTPMethod: http.MethodPut}, nil)
+		require.ErrorIs(t, err, ErrInvalidParam)
+	})
+}
+
+func TestRequest_Path(t *testing.T) {
+	for _, name := range []string{"ISteam/User", "ISteamUser?x=1", "ISteamUser#v"} {
+		for _, req := range []*Request{
+			{Interface: name, Method: "GetPlayerBans", Version: 1},
+			{Interface: "ISteamUser", Method: name, Version: 1},
+		} {
+			require.ErrorIs(t, req.Validate(), ErrInvalidParam, name)
+		}
+	}
+
+	req := &Request{Interface: "ISteam User", Method: "Get%Bans", Version: 1}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "/ISteam%20User/Get%25Bans/v1", req.Path())
+	assert.Equal(t, "/ISteamUser/GetPlayerBans/v1", (&Request{Interface: "ISteamUser", Method: "GetPlayerBans", Version: 1}).Path())
+}
+
+func TestClient_GetServerList_Escaping(t *testing.T) {
+	var filter string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter = r.URL.Query().Get("filter")
+
+		w.Write([]byte(`{"response":{"servers":[]}}`))
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(newConfig(ts.URL)).GetServerList(&GetServerListFilter{AppID: 108600, NameMatch: "Rock & Roll #1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `\appid\108600\name_match\*Rock & Roll #1*`, filter)
+}
@@ -0,0 +1,330 @@
+package steamweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// UnsupportedFiltersByApp is a built-in table of Steam filter params which
+// master servers ignore for the game keyed by AppID. Entries are replaced
+// by Config.AppUnsupportedFilters. Params are named as in the filter string,
+// e.g. "map" or "password".
+var UnsupportedFiltersByApp = map[int][]string{
+	108600: {"map", "password", "proxy", "napp", "white", "gamedata", "gamedataor"}, // Project Zomboid.
+}
+
+var ErrIgnoredFilters = errors.New("filter params are not supported")
+
+// IgnoredFiltersError is returned for a strict filter with params Steam
+// does not support for the app and the client cannot emulate. It matches
+// ErrIgnoredFilters with errors.Is.
+type IgnoredFiltersError struct {
+	AppID  int
+	Params []string
+}
+
+func (e *IgnoredFiltersError) Error() string {
+	return fmt.Sprintf("%s: appid %d: %s", ErrIgnoredFilters, e.AppID, strings.Join(e.Params, ", "))
+}
+
+func (e *IgnoredFiltersError) Is(target error) bool {
+	return target == ErrIgnoredFilters
+}
+
+// FilterPlan tells where params of GetServerListFilter are applied.
+type FilterPlan struct {
+	// Remote params are sent to Steam.
+	Remote []string `json:"remote"`
+
+	// Local params are not supported by Steam for the app, they are
+	// emulated on returned servers.
+	Local []string `json:"local"`
+
+	// Ignored params are not supported by Steam for the app and cannot be
+	// emulated because servers do not report the data, e.g. "password".
+	Ignored []string `json:"ignored"`
+}
+
+// filterParam is a Steam filter param of GetServerListFilter.
+type filterParam struct {
+	name  string
+	isSet func(g *GetServerListFilter) bool
+	clear func(g *GetServerListFilter)
+
+	// emulate returns a predicate applying the param to returned servers,
+	// it is nil when servers lack the data.
+	emulate func(g *GetServerListFilter) ServerPredicate
+}
+
+// filterParams are Steam filter params in the order of the filter string.
+// AppID is always sent, it is not listed.
+var filterParams = []filterParam{
+	{
+		name:    "dedicated",
+		isSet:   func(g *GetServerListFilter) bool { return g.Dedicated },
+		clear:   func(g *GetServerListFilter) { g.Dedicated = false },
+		emulate: func(*GetServerListFilter) ServerPredicate { return func(s *Server) bool { return s.Dedicated } },
+	},
+	{
+		name:    "secure",
+		isSet:   func(g *GetServerListFilter) bool { return g.Secure },
+		clear:   func(g *GetServerListFilter) { g.Secure = false },
+		emulate: func(*GetServerListFilter) ServerPredicate { return SecureIs(true) },
+	},
+	{
+		name:  "gamedir",
+		isSet: func(g *GetServerListFilter) bool { return g.GameDir != "" },
+		clear: func(g *GetServerListFilter) { g.GameDir = "" },
+		emulate: func(g *GetServerListFilter) ServerPredicate {
+			return func(s *Server) bool { return strings.EqualFold(s.GameDir, g.GameDir) }
+		},
+	},
+	{
+		name:    "map",
+		isSet:   func(g *GetServerListFilter) bool { return g.Map != "" },
+		clear:   func(g *GetServerListFilter) { g.Map = "" },
+		emulate: func(g *GetServerListFilter) ServerPredicate { return mapIs(g.Map) },
+	},
+	{
+		name:    "linux",
+		isSet:   func(g *GetServerListFilter) bool { return g.Linux },
+		clear:   func(g *GetServerListFilter) { g.Linux = false },
+		emulate: func(*GetServerListFilter) ServerPredicate { return OSIn(OSLinux) },
+	},
+	{
+		name:  "password",
+		isSet: func(g *GetServerListFilter) bool { return g.NoPassword },
+		clear: func(g *GetServerListFilter) { g.NoPassword = false },
+	},
+	{
+		name:    "empty",
+		isSet:   func(g *GetServerListFilter) bool { return g.NotEmpty },
+		clear:   func(g *GetServerListFilter) { g.NotEmpty = false },
+		emulate: func(*GetServerListFilter) ServerPredicate { return PlayersAtLeast(1) },
+	},
+	{
+		name:    "full",
+		isSet:   func(g *GetServerListFilter) bool { return g.NotFull },
+		clear:   func(g *GetServerListFilter) { g.NotFull = false },
+		emulate: func(*GetServerListFilter) ServerPredicate { return FreeSlotsAtLeast(1) },
+	},
+	{
+		name:  "proxy",
+		isSet: func(g *GetServerListFilter) bool { return g.Proxy },
+		clear: func(g *GetServerListFilter) { g.Proxy = false },
+	},
+	{
+		name:  "napp",
+		isSet: func(g *GetServerListFilter) bool { return g.NotAppID != 0 },
+		clear: func(g *GetServerListFilter) { g.NotAppID = 0 },
+		emulate: func(g *GetServerListFilter) ServerPredicate {
+			return func(s *Server) bool { return s.AppID != g.NotAppID }
+		},
+	},
+	{
+		name:    "noplayers",
+		isSet:   func(g *GetServerListFilter) bool { return g.NoPlayers },
+		clear:   func(g *GetServerListFilter) { g.NoPlayers = false },
+		emulate: func(*GetServerListFilter) ServerPredicate { return PlayersAtMost(0) },
+	},
+	{
+		name:  "white",
+		isSet: func(g *GetServerListFilter) bool { return g.Whitelisted },
+		clear: func(g *GetServerListFilter) { g.Whitelisted = false },
+	},
+	{
+		name:    "gametype",
+		isSet:   func(g *GetServerListFilter) bool { return len(g.GameTypeTags) != 0 },
+		clear:   func(g *GetServerListFilter) { g.GameTypeTags = nil },
+		emulate: func(g *GetServerListFilter) ServerPredicate { return TagsInclude(g.GameTypeTags...) },
+	},
+	{
+		name:    "not_gametype",
+		isSet:   func(g *GetServerListFilter) bool { return len(g.NotGameTypeTags) != 0 },
+		clear:   func(g *GetServerListFilter) { g.NotGameTypeTags = nil },
+		emulate: func(g *GetServerListFilter) ServerPredicate { return TagsExclude(g.NotGameTypeTags...) },
+	},
+	{
+		name:  "gamedata",
+		isSet: func(g *GetServerListFilter) bool { return len(g.GameDataTags) != 0 },
+		clear: func(g *GetServerListFilter) { g.GameDataTags = nil },
+	},
+	{
+		name:  "gamedataor",
+		isSet: func(g *GetServerListFilter) bool { return len(g.GameDataOrTags) != 0 },
+		clear: func(g *GetServerListFilter) { g.GameDataOrTags = nil },
+	},
+	{
+		name:    "name_match",
+		isSet:   func(g *GetServerListFilter) bool { return g.NameMatch != "" },
+		clear:   func(g *GetServerListFilter) { g.NameMatch = "" },
+		emulate: func(g *GetServerListFilter) ServerPredicate { return NameGlob("*" + g.NameMatch + "*") },
+	},
+	{
+		name:  "version_match",
+		isSet: func(g *GetServerListFilter) bool { return g.VersionMatch != "" },
+		clear: func(g *GetServerListFilter) { g.VersionMatch = "" },
+		emulate: func(g *GetServerListFilter) ServerPredicate {
+			re := globRegexp(g.VersionMatch)
+
+			return func(s *Server) bool { return re.MatchString(s.Version) }
+		},
+	},
+	{
+		name:  "collapse_addr_hash",
+		isSet: func(g *GetServerListFilter) bool { return g.CollapseAddrHash },
+		clear: func(g *GetServerListFilter) { g.CollapseAddrHash = false },
+	},
+	{
+		name:    "gameaddr",
+		isSet:   func(g *GetServerListFilter) bool { return g.GameAddr != "" },
+		clear:   func(g *GetServerListFilter) { g.GameAddr = "" },
+		emulate: func(g *GetServerListFilter) ServerPredicate { return gameAddrIs(g.GameAddr) },
+	},
+	{
+		name:  "nor",
+		isSet: func(g *GetServerListFilter) bool { return len(g.NotOr) != 0 },
+		clear: func(g *GetServerListFilter) { g.NotOr = nil },
+	},
+	{
+		name:  "nand",
+		isSet: func(g *GetServerListFilter) bool { return len(g.NotAnd) != 0 },
+		clear: func(g *GetServerListFilter) { g.NotAnd = nil },
+	},
+}
+
+// mapIs matches servers running the map ignoring case. Servers may report
+// several maps separated by semicolons.
+func mapIs(name string) ServerPredicate {
+	return func(server *Server) bool {
+		return slices.ContainsFunc(strings.Split(server.Map, ";"), func(m string) bool {
+			return strings.EqualFold(strings.TrimSpace(m), name)
+		})
+	}
+}
+
+// gameAddrIs matches servers on the IP address, the port is optional.
+func gameAddrIs(addr string) ServerPredicate {
+	return func(server *Server) bool {
+		serverAddr, err := server.AddrPort()
+		if err != nil {
+			return false
+		}
+
+		if ip, err := netip.ParseAddr(addr); err == nil {
+			return serverAddr.Addr() == ip
+		}
+
+		addrPort, err := netip.ParseAddrPort(addr)
+		if err != nil {
+			return false
+		}
+
+		gameAddr, _ := server.GameAddrPort()
+
+		return serverAddr == addrPort || gameAddr == addrPort
+	}
+}
+
+// unsupportedFilters returns params Steam ignores keyed by AppID, the
+// built-in table is merged with Config.AppUnsupportedFilters.
+func unsupportedFilters(cfg *Config) map[int][]string {
+	apps := make(map[int][]string, len(UnsupportedFiltersByApp)+len(cfg.AppUnsupportedFilters))
+
+	for appID, params := range UnsupportedFiltersByApp {
+		apps[appID] = params
+	}
+
+	for appID, params := range cfg.AppUnsupportedFilters {
+		apps[appID] = params
+	}
+
+	return apps
+}
+
+// validateUnsupportedFilters reports unknown params of Config.AppUnsupportedFilters.
+func validateUnsupportedFilters(cfg *Config) []error {
+	var errs []error
+
+	for appID, params := range cfg.AppUnsupportedFilters {
+		for _, param := range params {
+			if !slices.ContainsFunc(filterParams, func(p filterParam) bool { return p.name == param }) {
+				errs = append(errs, fmt.Errorf("%w: %s: %d: unknown filter param %q",
+					ErrConfigInvalidParam, "app_unsupported_filters", appID, param))
+			}
+		}
+	}
+
+	return errs
+}
+
+// FilterPlan reports which params of the filter are sent to Steam and which
+// are emulated or ignored because Steam does not support them for the app.
+func (c *Client) FilterPlan(filter *GetServerListFilter) FilterPlan {
+	_, _, plan := c.splitFilter(filter)
+
+	return plan
+}
+
+// planFilter validates the filter and splits it. Ignored params fail
+// a strict filter with *IgnoredFiltersError, otherwise they are logged.
+func (c *Client) planFilter(
+	ctx context.Context, filter *GetServerListFilter,
+) (*GetServerListFilter, *GetServerListFilter, FilterPlan, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, FilterPlan{}, err
+	}
+
+	remote, local, plan := c.splitFilter(filter)
+
+	if len(plan.Ignored) == 0 {
+		return remote, local, plan, nil
+	}
+
+	if filter.Strict {
+		return nil, nil, plan, &IgnoredFiltersError{AppID: filter.AppID, Params: plan.Ignored}
+	}
+
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "steamweb: filter params ignored",
+			slog.Int("appid", filter.AppID),
+			slog.Any("params", plan.Ignored),
+		)
+	}
+
+	return remote, local, plan, nil
+}
+
+// splitFilter returns the filter sent to Steam without unsupported params
+// and the filter applied to returned servers with emulated params in Match.
+func (c *Client) splitFilter(filter *GetServerListFilter) (*GetServerListFilter, *GetServerListFilter, FilterPlan) {
+	remote, local := *filter, *filter
+	plan := FilterPlan{Remote: []string{"appid"}}
+	unsupported := c.unsupportedFilters[filter.AppID]
+
+	local.Match = slices.Clone(filter.Match)
+
+	for _, param := range filterParams {
+		switch {
+		case !param.isSet(filter):
+		case !slices.Contains(unsupported, param.name):
+			plan.Remote = append(plan.Remote, param.name)
+		case param.emulate != nil:
+			plan.Local = append(plan.Local, param.name)
+			local.Match = append(local.Match, param.emulate(filter))
+
+			param.clear(&remote)
+		default:
+			plan.Ignored = append(plan.Ignored, param.name)
+
+			param.clear(&remote)
+		}
+	}
+
+	return &remote, &local, plan
+}
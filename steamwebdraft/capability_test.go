@@ -0,0 +1,144 @@
+package steamweb
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_FilterPlan(t *testing.T) {
+	client := NewClient(newConfig(""))
+
+	filter := &GetServerListFilter{
+		AppID:        108600,
+		Dedicated:    true,
+		Map:          "Muldraugh, KY",
+		NoPassword:   true,
+		NotAppID:     4000,
+		GameTypeTags: []string{"pvp"},
+		NameMatch:    "PvP",
+	}
+
+	assert.Equal(t, FilterPlan{
+		Remote:  []string{"appid", "dedicated", "gametype", "name_match"},
+		Local:   []string{"map", "napp"},
+		Ignored: []string{"password"},
+	}, client.FilterPlan(filter))
+
+	assert.Equal(t, FilterPlan{
+		Remote: []string{"appid", "dedicated", "map", "password", "napp", "gametype", "name_match"},
+	}, client.FilterPlan(&GetServerListFilter{
+		AppID:        730,
+		Dedicated:    true,
+		Map:          "de_dust2",
+		NoPassword:   true,
+		NotAppID:     4000,
+		GameTypeTags: []string{"pvp"},
+		NameMatch:    "PvP",
+	}))
+
+	t.Run("config", func(t *testing.T) {
+		cfg := newConfig("")
+		cfg.AppUnsupportedFilters = map[int][]string{108600: {}, 730: {"map", "secure"}}
+
+		client := NewClient(cfg)
+
+		assert.Equal(t, FilterPlan{Remote: []string{"appid", "map"}}, client.FilterPlan(&GetServerListFilter{AppID: 108600, Map: "x"}))
+		assert.Equal(t, FilterPlan{Remote: []string{"appid"}, Local: []string{"map"}}, client.FilterPlan(&GetServerListFilter{AppID: 730, Map: "x"}))
+
+		cfg.AppUnsupportedFilters[730] = []string{"mapp"}
+		require.ErrorIs(t, cfg.Validate(), ErrConfigInvalidParam)
+	})
+}
+
+func TestClient_GetServerList_EmulatedFilters(t *testing.T) {
+	var query url.Values
+
+	body := `{"response":{"servers":[` +
+		`{"addr":"127.0.0.1:16261","gameport":16262,"name":"A","appid":108600,"players":3,"map":"Muldraugh, KY"},` +
+		`{"addr":"127.0.0.2:16261","name":"B","appid":108600,"players":2,"map":"vehicle_interior;muldraugh, ky"},` +
+		`{"addr":"127.0.0.3:16261","name":"C","appid":108600,"players":1,"map":"Riverside, KY"}` +
+		`]}}`
+
+	client := NewClient(newConfig("http://steam.test"), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		query = req.URL.Query()
+
+		return jsonResponse(req, body), nil
+	})))
+
+	servers, err := client.GetServerList(&GetServerListFilter{AppID: 108600, Map: "Muldraugh, KY", NoPassword: true})
+	require.NoError(t, err)
+	assert.Equal(t, `\appid\108600`, query.Get("filter"))
+	assert.Len(t, servers, 2)
+
+	var names []string
+
+	for server, err := range client.StreamServerList(context.Background(), &GetServerListFilter{AppID: 108600, Map: "muldraugh, ky"}) {
+		require.NoError(t, err)
+
+		names = append(names, server.Name)
+	}
+
+	assert.Equal(t, `\appid\108600`, query.Get("filter"))
+	assert.Equal(t, []string{"A", "B"}, names)
+
+	servers, err = client.GetServerList(&GetServerListFilter{AppID: 108600, NotAppID: 108600 + 1, Map: "Riverside, KY"})
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "C", servers[0].Name)
+}
+
+func TestClient_GetServerListWithPlan(t *testing.T) {
+	var buf bytes.Buffer
+
+	client := NewClient(newConfig("http://steam.test"),
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, `{"response":{"servers":[{"addr":"127.0.0.1:16261","name":"A","appid":108600,"map":"Muldraugh, KY"}]}}`), nil
+		})))
+
+	servers, plan, err := client.GetServerListWithPlan(context.Background(),
+		&GetServerListFilter{AppID: 108600, Map: "Muldraugh, KY", NoPassword: true})
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+	assert.Equal(t, FilterPlan{Remote: []string{"appid"}, Local: []string{"map"}, Ignored: []string{"password"}}, plan)
+
+	records := decodeLogs(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "steamweb: filter params ignored", records[0]["msg"])
+	assert.Equal(t, []any{"password"}, records[0]["params"])
+
+	_, plan, err = client.GetServerListWithPlan(context.Background(), &GetServerListFilter{AppID: 108600, NoPassword: true, Strict: true})
+	require.ErrorIs(t, err, ErrIgnoredFilters)
+	assert.Equal(t, []string{"password"}, plan.Ignored)
+
+	var ignored *IgnoredFiltersError
+	require.ErrorAs(t, err, &ignored)
+	assert.Equal(t, "filter params are not supported: appid 108600: password", ignored.Error())
+
+	for _, err := range client.StreamServerList(context.Background(), &GetServerListFilter{AppID: 108600, Proxy: true, Strict: true}) {
+		require.ErrorIs(t, err, ErrIgnoredFilters)
+	}
+
+	servers, plan, err = client.GetServerListWithPlan(context.Background(), &GetServerListFilter{AppID: 108600, Strict: true})
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+	assert.Equal(t, FilterPlan{Remote: []string{"appid"}}, plan)
+}
+
+func TestGameAddrIs(t *testing.T) {
+	server := &Server{Addr: "127.0.0.1:16261", GamePort: 16262}
+
+	assert.True(t, gameAddrIs("127.0.0.1")(server))
+	assert.True(t, gameAddrIs("127.0.0.1:16261")(server))
+	assert.True(t, gameAddrIs("127.0.0.1:16262")(server))
+	assert.False(t, gameAddrIs("127.0.0.1:16263")(server))
+	assert.False(t, gameAddrIs("127.0.0.2")(server))
+	assert.False(t, gameAddrIs("127.0.0.1")(&Server{Addr: "invalid"}))
+}
@@ -0,0 +1,320 @@
+// Package cassette provides an http.RoundTripper that records Steam Web API
+// interactions to disk and replays them later without network access.
+//
+// Recorded URLs never contain the API key: the key query parameter is removed
+// before an interaction is stored, and request headers are not stored at all.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// KeyParam is the query parameter holding the Steam Web API key.
+const KeyParam = "key"
+
+var (
+	ErrNoInteraction = errors.New("cassette: no recorded interaction matches request")
+	ErrUnknownMode   = errors.New("cassette: unknown mode")
+)
+
+// Mode defines whether Recorder talks to the network or to the cassette file.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette file only. Every
+	// interaction is served once, in recorded order for identical requests.
+	// Requests that do not match an interaction left fail with ErrNoInteraction.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real transport and records every
+	// interaction. Call Recorder.Save to write the cassette to disk.
+	ModeRecord
+	// ModeReplayRepeat is ModeReplay that keeps serving the last matching
+	// interaction once all of them are used.
+	ModeReplayRepeat
+)
+
+type (
+	// Cassette is a list of recorded interactions as stored on disk.
+	Cassette struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+
+	// Interaction is a single recorded request/response pair.
+	Interaction struct {
+		Request  Request  `json:"request"`
+		Response Response `json:"response"`
+	}
+
+	// Request describes a recorded request. URL and Params never contain the API key.
+	Request struct {
+		Method    string     `json:"method"`
+		URL       string     `json:"url"`
+		Interface string     `json:"interface"`
+		APIMethod string     `json:"api_method"`
+		Version   string     `json:"version"`
+		Params    url.Values `json:"params,omitempty"`
+	}
+
+	// Response describes a recorded response.
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body"`
+	}
+)
+
+// Recorder is http.RoundTripper recording to or replaying from a cassette file.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New creates Recorder for the cassette file at path. In ModeReplay the file
+// is loaded immediately. In ModeRecord requests are sent with transport,
+// http.DefaultTransport is used when transport is nil.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	recorder := &Recorder{path: path, mode: mode, transport: transport}
+
+	switch mode {
+	case ModeReplay, ModeReplayRepeat:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("cassette: decode %s: %w", path, err)
+		}
+
+		recorder.used = make([]bool, len(recorder.cassette.Interactions))
+	case ModeRecord:
+		if recorder.transport == nil {
+			recorder.transport = http.DefaultTransport
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownMode, mode)
+	}
+
+	return recorder, nil
+}
+
+// RoundTrip implements http.RoundTripper. The body of the request is read
+// and closed in both modes, the request of the caller gets an unread copy
+// of it and is not modified otherwise.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	clone := req.Clone(req.Context())
+	if body != nil {
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded, err := newRequest(clone, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, clone, recorded)
+	}
+
+	return r.replay(req, recorded)
+}
+
+// Interactions returns a copy of interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes recorded interactions to the cassette file creating parent
+// directories when needed. It is a no-op in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil { //nolint:mnd // Directory permissions.
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o600) //nolint:mnd // File permissions.
+}
+
+// record sends clone of req and records the interaction.
+func (r *Recorder) record(req, clone *http.Request, recorded Request) (*http.Response, error) {
+	// Recorded bodies must be readable, so compression is left to the
+	// transport, which decodes responses it asked to compress.
+	clone.Header.Del("Accept-Encoding")
+
+	res, err := r.transport.RoundTrip(clone)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       string(body),
+		},
+	}
+
+	// Cookies may carry session data and are useless for replay.
+	interaction.Response.Header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	return interaction.Response.toHTTP(req), nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Interactions that have not been served yet go first, so that repeated
+	// identical requests replay in recorded order.
+	last := -1
+
+	for i := range r.cassette.Interactions {
+		if !r.cassette.Interactions[i].Request.matches(recorded) {
+			continue
+		}
+
+		last = i
+
+		if !r.used[i] {
+			r.used[i] = true
+
+			return r.cassette.Interactions[i].Response.toHTTP(req), nil
+		}
+	}
+
+	if last == -1 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+	}
+
+	if r.mode != ModeReplayRepeat {
+		return nil, fmt.Errorf("%w: %s %s: all matching interactions are used", ErrNoInteraction, recorded.Method, recorded.URL)
+	}
+
+	return r.cassette.Interactions[last].Response.toHTTP(req), nil
+}
+
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method &&
+		r.Interface == other.Interface &&
+		r.APIMethod == other.APIMethod &&
+		r.Version == other.Version &&
+		paramsEqual(r.Params, other.Params)
+}
+
+func (r Response) toHTTP(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// newRequest describes req with the body in the recorded form with the API
+// key scrubbed. Form encoded request bodies are merged into Params.
+func newRequest(req *http.Request, body []byte) (Request, error) {
+	u := *req.URL
+	params := u.Query()
+	params.Del(KeyParam)
+	u.RawQuery = params.Encode()
+
+	if len(body) != 0 && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return Request{}, err
+		}
+
+		for name, values := range form {
+			if name != KeyParam {
+				params[name] = append(params[name], values...)
+			}
+		}
+	}
+
+	recorded := Request{
+		Method: req.Method,
+		URL:    u.String(),
+		Params: params,
+	}
+
+	// Steam Web API paths look like /ISteamUser/GetPlayerBans/v1.
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 3 { //nolint:mnd // Interface, method and version.
+		recorded.Interface = parts[len(parts)-3]
+		recorded.APIMethod = parts[len(parts)-2]
+		recorded.Version = parts[len(parts)-1]
+	}
+
+	return recorded, nil
+}
+
+func paramsEqual(a, b url.Values) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
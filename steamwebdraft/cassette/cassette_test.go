@@ -0,0 +1,174 @@
+package cassette_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+	"github.com/gorcon/steamweb/steamwebdraft/cassette"
+)
+
+const testKey = "R1jamSsz17LHA9WgDW099YGfCs4fn0m0"
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("network is not allowed in replay")
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprintf(w, `{"players":[{"SteamId":%q,"VACBanned":true,"NumberOfVACBans":1,"EconomyBan":"none"}]}`, r.URL.Query().Get("steamids"))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "testdata", "bans.json")
+
+	recorder, err := cassette.New(path, cassette.ModeRecord, nil)
+	require.NoError(t, err)
+
+	client := steamweb.NewClient(&steamweb.Config{Key: testKey, URL: ts.URL}, steamweb.WithTransport(recorder))
+
+	recorded, err := client.GetPlayerBans("7656119")
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), testKey)
+
+	interactions := recorder.Interactions()
+	if assert.Len(t, interactions, 1) {
+		assert.Equal(t, "ISteamUser", interactions[0].Request.Interface)
+		assert.Equal(t, "GetPlayerBans", interactions[0].Request.APIMethod)
+		assert.Equal(t, "v1", interactions[0].Request.Version)
+	}
+
+	ts.Close()
+
+	replayer, err := cassette.New(path, cassette.ModeReplay, failingTransport{})
+	require.NoError(t, err)
+
+	client = steamweb.NewClient(&steamweb.Config{Key: "another key", URL: ts.URL}, steamweb.WithTransport(replayer))
+
+	t.Run("matched", func(t *testing.T) {
+		replayed, err := client.GetPlayerBans("7656119")
+		require.NoError(t, err)
+		assert.Equal(t, recorded, replayed)
+	})
+
+	t.Run("unmatched", func(t *testing.T) {
+		_, err := client.GetPlayerBans("7656120")
+		assert.ErrorIs(t, err, cassette.ErrNoInteraction)
+	})
+
+	t.Run("used", func(t *testing.T) {
+		_, err := client.GetPlayerBans("7656119")
+		assert.ErrorIs(t, err, cassette.ErrNoInteraction)
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		repeater, err := cassette.New(path, cassette.ModeReplayRepeat, failingTransport{})
+		require.NoError(t, err)
+
+		client := steamweb.NewClient(&steamweb.Config{Key: "another key", URL: ts.URL}, steamweb.WithTransport(repeater))
+
+		for range 2 {
+			replayed, err := client.GetPlayerBans("7656119")
+			require.NoError(t, err)
+			assert.Equal(t, recorded, replayed)
+		}
+	})
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+
+	return nil
+}
+
+func TestRecorder_RoundTrip_FormBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		fmt.Fprintf(w, `{"steamid":%q}`, r.PostForm.Get("steamid"))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "form.json")
+
+	recorder, err := cassette.New(path, cassette.ModeRecord, nil)
+	require.NoError(t, err)
+
+	body := &closeRecorder{Reader: strings.NewReader("steamid=1&key=" + testKey)}
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/ISteamUser/Test/v1", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	res, err := recorder.RoundTrip(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Same(t, req, res.Request)
+	assert.True(t, body.closed, "body of the caller must be closed")
+	assert.Equal(t, "gzip", req.Header.Get("Accept-Encoding"))
+
+	// The caller gets an unread copy of the body.
+	data, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "steamid=1&key="+testKey, string(data))
+
+	interactions := recorder.Interactions()
+	require.Len(t, interactions, 1)
+	assert.Equal(t, url.Values{"steamid": {"1"}}, interactions[0].Request.Params)
+	assert.JSONEq(t, `{"steamid":"1"}`, interactions[0].Response.Body)
+
+	t.Run("replay", func(t *testing.T) {
+		require.NoError(t, recorder.Save())
+
+		replayer, err := cassette.New(path, cassette.ModeReplay, failingTransport{})
+		require.NoError(t, err)
+
+		body := &closeRecorder{Reader: strings.NewReader("key=another&steamid=1")}
+
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/ISteamUser/Test/v1", body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		res, err := replayer.RoundTrip(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.True(t, body.closed, "body of the caller must be closed")
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("missing cassette", func(t *testing.T) {
+		_, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), cassette.ModeReplay, nil)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := cassette.New("", cassette.Mode(42), nil)
+		assert.ErrorIs(t, err, cassette.ErrUnknownMode)
+	})
+}
@@ -6,12 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
+	"log/slog"
 	"net/http"
-	"sort"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// URL templates of the methods, the first verb is the api key.
+//
+// Deprecated: URL templates do not escape params, use Request or Client.Call instead.
 const (
 	GetPlayerBansURL = "/ISteamUser/GetPlayerBans/v1?key=%s&steamids=%s"
 	GetServerListURL = "/IGameServersService/GetServerList/v1?key=%s&limit=%d&filter=%s"
@@ -24,147 +29,358 @@ var (
 
 // Client is http client for getting requests to ISteamUser api.
 type Client struct {
-	config *Config
-	http   *http.Client
+	config      *Config
+	keys        *keyPool
+	http        *http.Client
+	middlewares []Middleware
+	do          RequestFunc
+	cache       *responseCache
+	flights     flightGroup
+
+	instrumenters      instrumenters
+	logger             *slog.Logger
+	serverNames        *serverNames
+	unsupportedFilters map[int][]string
+
+	// transportErr is set when Config.Transport can not be used.
+	transportErr error
 }
 
 // NewClient creates and returns a new Client instance initialized with the provided configuration.
-func NewClient(cfg *Config) *Client {
+// Problems with Config.Transport, such as unreadable certificates, are returned
+// from every request; call Config.Validate to detect them beforehand.
+func NewClient(cfg *Config, opts ...Option) *Client {
 	cfg.SetDefaults()
 
-	return &Client{
-		config: cfg,
+	client := &Client{
+		config:             cfg,
+		keys:               newKeyPool(cfg),
+		logger:             cfg.Log.Logger,
+		serverNames:        newServerNames(cfg),
+		unsupportedFilters: unsupportedFilters(cfg),
 		http: &http.Client{
 			Timeout: cfg.Timeout,
-			Transport: &http.Transport{
-				DialContext: (&net.Dialer{
-					Timeout:       cfg.Transport.Dialer.Timeout,
-					Deadline:      cfg.Transport.Dialer.Deadline,
-					FallbackDelay: cfg.Transport.Dialer.FallbackDelay,
-					KeepAlive:     cfg.Transport.Dialer.KeepAlive,
-				}).DialContext,
-				TLSHandshakeTimeout: cfg.Transport.TLSHandshakeTimeout,
-			},
 		},
 	}
+
+	transport, err := cfg.Transport.newHTTPTransport()
+	if err != nil {
+		client.transportErr = err
+	} else {
+		client.http.Transport = transport
+	}
+
+	if cfg.Cache.Enabled {
+		client.cache = newResponseCache(NewLRUCache(cfg.Cache.MaxEntries, cfg.Cache.MaxBytes), &cfg.Cache, cfg.URL)
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	client.do = chain(client.http.Do, client.middlewares...)
+
+	return client
 }
 
 // GetPlayerBans returns Community, VAC, and Economy ban statuses for given players.
 // Example URL: http://api.steampowered.com/ISteamUser/GetPlayerBans/v1/?key=XXXXXXXXXXXXXXXXX&steamids=XXXXXXXX,YYYYY
 func (c *Client) GetPlayerBans(steamIDs ...string) ([]PlayerBans, error) {
+	return c.GetPlayerBansContext(context.Background(), steamIDs...)
+}
+
+// GetPlayerBansContext is like GetPlayerBans but uses ctx for the request.
+func (c *Client) GetPlayerBansContext(ctx context.Context, steamIDs ...string) ([]PlayerBans, error) {
 	response := GetPlayerBansResponse{}
 
-	// Return empty ban history with disabled client.
-	if c.config.Disabled {
-		return response.Players, nil
+	req := &Request{
+		Interface: "ISteamUser",
+		Method:    "GetPlayerBans",
+		Version:   1,
+		Params:    url.Values{"steamids": {strings.Join(steamIDs, ",")}},
 	}
 
-	uri := c.config.URL + fmt.Sprintf(GetPlayerBansURL, c.config.Key, strings.Join(steamIDs, ","))
+	// Disabled client leaves response untouched and returns empty ban history.
+	err := c.call(ctx, req, func(body []byte) (int, error) {
+		if err := json.Unmarshal(body, &response); err != nil {
+			return 0, err
+		}
 
-	body, err := c.sendRequest(context.Background(), http.MethodGet, uri, http.NoBody)
+		return len(response.Players), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, err
-	}
-
 	return response.Players, nil
 }
 
-// GetServerList returns Steam servers from filter query.
+// GetServerList returns Steam servers from filter query sorted by filter.Sort.
+// Invalid filter fails with *FilterError before the request is sent. Params
+// Steam does not support for the app are applied to returned servers, see
+// GetServerListWithPlan.
 // Example URL: http://api.steampowered.com/IGameServersService/GetServerList/v1/?key=XXXXXXXXXXXXXXXXX&limit=X&filter=F
 func (c *Client) GetServerList(filter *GetServerListFilter) ([]Server, error) {
+	return c.GetServerListContext(context.Background(), filter)
+}
+
+// GetServerListContext is like GetServerList but uses ctx for the request.
+func (c *Client) GetServerListContext(ctx context.Context, filter *GetServerListFilter) ([]Server, error) {
+	servers, _, err := c.GetServerListWithPlan(ctx, filter)
+
+	return servers, err
+}
+
+// GetServerListWithPlan is like GetServerListContext and also returns the
+// plan telling which filter params were applied by Steam, which were
+// emulated on returned servers and which were ignored.
+func (c *Client) GetServerListWithPlan(
+	ctx context.Context, filter *GetServerListFilter,
+) ([]Server, FilterPlan, error) {
 	response := GetServerListResponse{}
 
 	// Return empty servers list with disabled client.
 	if c.config.Disabled {
-		return response.Response.Servers, nil
+		return response.Response.Servers, FilterPlan{}, nil
+	}
+
+	remote, local, plan, err := c.planFilter(ctx, filter)
+	if err != nil {
+		return nil, plan, err
 	}
 
+	var servers []Server
+
+	err = c.call(ctx, newServerListRequest(remote), func(body []byte) (int, error) {
+		if err := json.Unmarshal(body, &response); err != nil {
+			return 0, err
+		}
+
+		servers = c.filterServers(response.Response.Servers, local)
+
+		return len(servers), nil
+	})
+	if err != nil {
+		return nil, plan, err
+	}
+
+	return servers, plan, nil
+}
+
+func newServerListRequest(filter *GetServerListFilter) *Request {
 	limit := filter.Limit
 	if limit == 0 {
 		limit = DefaultLimit
 	}
 
-	uri := c.config.URL + fmt.Sprintf(GetServerListURL, c.config.Key, limit, filter.String())
+	return &Request{
+		Interface: "IGameServersService",
+		Method:    "GetServerList",
+		Version:   1,
+		Params: url.Values{
+			"limit":  {strconv.Itoa(limit)},
+			"filter": {filter.String()},
+		},
+	}
+}
+
+// KeyStats returns usage and health of every API key in the pool.
+func (c *Client) KeyStats() []KeyStats {
+	return c.keys.stats()
+}
+
+// CacheStats returns counters of the response cache.
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+
+	return c.cache.stats()
+}
+
+// fetch returns response body from cache when it is enabled or sends the request.
+func (c *Client) fetch(ctx context.Context, r *Request) ([]byte, error) {
+	if c.cache == nil {
+		return c.coalesce(ctx, r)
+	}
+
+	return c.cache.fetch(ctx, r, c.coalesce)
+}
+
+// sendRequest sends request with a key from the pool and returns response body.
+func (c *Client) sendRequest(ctx context.Context, r *Request) ([]byte, error) {
+	var resBody []byte
+
+	err := c.sendRequestFunc(ctx, r, func(body io.Reader) error {
+		var err error
 
-	body, err := c.sendRequest(context.Background(), http.MethodGet, uri, http.NoBody)
+		resBody, err = io.ReadAll(&maxBytesReader{r: body, limit: c.config.MaxResponseSize})
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, err
+	return resBody, nil
+}
+
+// sendRequestFunc sends request with a key from the pool and passes body of
+// successful response to read. When Steam rejects the key with 403 or 429
+// status, the request is repeated with another key. A 403 of the second key
+// is returned, it is sent by methods the keys are not allowed to call.
+func (c *Client) sendRequestFunc(ctx context.Context, r *Request, read func(body io.Reader) error) error {
+	if c.transportErr != nil {
+		return c.transportErr
 	}
 
-	return c.filterServers(response.Response.Servers, filter), nil
+	var (
+		err      error
+		keyRetry keyRetry
+	)
+
+	for attempt := 1; attempt <= max(c.keys.size(), 1); attempt++ {
+		var retry bool
+
+		retry, err = c.sendRequestWithKey(ctx, r, attempt, &keyRetry, read)
+		if !retry {
+			return err
+		}
+	}
+
+	return err
 }
 
-func (c *Client) sendRequest(ctx context.Context, method, uri string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, method, uri, body)
+func (c *Client) sendRequestWithKey(
+	ctx context.Context, r *Request, attempt int, keyRetry *keyRetry, read func(body io.Reader) error,
+) (retry bool, err error) {
+	info := RequestInfo{
+		Interface:  r.Interface,
+		Method:     r.Method,
+		Version:    r.Version,
+		HTTPMethod: r.httpMethod(),
+		Attempt:    attempt,
+	}
+
+	key, err := c.keys.acquire()
 	if err != nil {
-		return nil, err
+		c.logRequest(ctx, r, info, "", RequestStats{Err: err}, false, nil)
+
+		return false, err
 	}
 
-	res, err := c.http.Do(req)
+	info.Key = maskKey(key.value)
+	ctx = c.instrumenters.RequestStarted(ctx, info)
+	start := time.Now()
+	status := 0
+	uri := ""
+	body := &countingReader{}
+	decoded := &countingReader{}
+	dump := &limitedBuffer{}
+
+	defer func() {
+		stats := RequestStats{
+			StatusCode:   status,
+			Duration:     time.Since(start),
+			Bytes:        body.n,
+			DecodedBytes: decoded.n,
+			Err:          err,
+		}
+
+		requestSent(ctx, status)
+		c.instrumenters.RequestFinished(ctx, info, stats)
+		c.logRequest(ctx, r, info, uri, stats, retry, dump.buf)
+	}()
+
+	req, err := c.newHTTPRequest(ctx, r, key.value)
 	if err != nil {
-		return nil, err
+		return false, redactError(err, key.value)
+	}
+
+	uri = redactString(req.URL.String(), key.value)
+
+	res, err := c.do(req)
+	if err != nil {
+		return false, redactError(err, key.value)
 	}
 
 	if res != nil {
 		defer res.Body.Close()
 	} else {
-		return nil, ErrEmptyResponse
+		return false, ErrEmptyResponse
+	}
+
+	status = res.StatusCode
+	retry = c.keys.release(key, res.StatusCode, keyRetry)
+	body.r = res.Body
+
+	if c.logger != nil && c.config.Log.DumpBodies {
+		// One more byte shows that the dump is truncated.
+		dump.limit = c.config.Log.DumpLimit + 1
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d %s", ErrWrongStatusCode, res.StatusCode, res.Status)
+		if dump.limit > 0 {
+			if decoded.r, err = decodeContent(body, res.Header.Get("Content-Encoding")); err == nil {
+				_, _ = io.Copy(io.Discard, io.LimitReader(io.TeeReader(decoded, dump), int64(dump.limit)))
+			}
+		}
+
+		return retry, fmt.Errorf("%w: %d %s", ErrWrongStatusCode, res.StatusCode, res.Status)
 	}
 
-	resBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	if decoded.r, err = decodeContent(body, res.Header.Get("Content-Encoding")); err != nil {
+		return false, truncatedError(err)
 	}
 
-	return resBody, nil
+	if err := read(io.TeeReader(decoded, dump)); err != nil {
+		return false, redactError(truncatedError(err), key.value)
+	}
+
+	return false, nil
 }
 
 func (c *Client) filterServers(servers []Server, filter *GetServerListFilter) []Server {
-	if filter.NoHidden || filter.NoDefaultServers {
+	if filter.local() {
 		removeAddrs := make(map[string]bool)
 
 		for i := range servers {
-			server := &servers[i]
-
-			if filter.NoHidden && strings.Contains(server.GameType, "hidden") {
-				removeAddrs[server.Addr] = true
-
-				continue
-			}
-
-			if filter.NoDefaultServers {
-				for _, name := range c.config.DefaultServerNames {
-					if server.Name == name {
-						removeAddrs[server.Addr] = true
-
-						continue
-					}
-				}
+			if c.skipServer(&servers[i], filter) {
+				removeAddrs[servers[i].Addr] = true
 			}
 		}
 
 		servers = c.removeFilteredServers(servers, removeAddrs)
 	}
 
-	sort.Slice(servers, func(i, j int) bool {
-		return servers[i].Players > servers[j].Players
-	})
+	SortServers(servers, filter.Sort...)
 
 	return servers
 }
 
+// skipServer reports whether the server is removed by custom filters.
+func (c *Client) skipServer(server *Server, filter *GetServerListFilter) bool {
+	tags := server.Tags()
+
+	if filter.NoHidden && tags.Has("hidden") {
+		return true
+	}
+
+	if !tags.HasAll(filter.GameTypeTags...) || tags.HasAny(filter.NotGameTypeTags...) {
+		return true
+	}
+
+	if filter.NoDefaultServers && c.serverNames.match(server) {
+		return true
+	}
+
+	if !matchRegions(server, filter.Regions) {
+		return true
+	}
+
+	return !filter.Matches(server)
+}
+
 func (c *Client) removeFilteredServers(servers []Server, addrs map[string]bool) []Server {
 	result := make([]Server, 0, len(servers))
 
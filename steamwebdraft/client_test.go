@@ -68,7 +68,7 @@ func TestClient_GetServerList(t *testing.T) {
 	}{
 		{
 			name:   "native filters",
-			filter: &GetServerListFilter{},
+			filter: &GetServerListFilter{AppID: 108600},
 			want: []Server{
 				{Addr: "127.0.0.4:16261", GamePort: 16261, SteamID: "90268799310246930", Name: "My PZ Server", AppID: 108600, GameDir: "zomboid", Version: "1.0.0.0", Product: "zomboid", Region: -1, Players: 13, MaxPlayers: 32, Bots: 0, Map: "Muldraugh, KY", Secure: true, Dedicated: true, OS: "w", GameType: "hidden;hosted"},
 				{Addr: "127.0.0.3:16260", GamePort: 16260, SteamID: "90268200350011416", Name: "Best Server", AppID: 108600, GameDir: "zomboid", Version: "1.0.0.0", Product: "zomboid", Region: -1, Players: 9, MaxPlayers: 30, Bots: 0, Map: "Muldraugh, KY", Secure: true, Dedicated: true, OS: "l", GameType: ""},
@@ -79,7 +79,7 @@ func TestClient_GetServerList(t *testing.T) {
 		},
 		{
 			name:   "custom filters",
-			filter: &GetServerListFilter{NoDefaultServers: true},
+			filter: &GetServerListFilter{AppID: 108600, NoDefaultServers: true},
 			want: []Server{
 				{Addr: "127.0.0.3:16260", GamePort: 16260, SteamID: "90268200350011416", Name: "Best Server", AppID: 108600, GameDir: "zomboid", Version: "1.0.0.0", Product: "zomboid", Region: -1, Players: 9, MaxPlayers: 30, Bots: 0, Map: "Muldraugh, KY", Secure: true, Dedicated: true, OS: "l", GameType: ""},
 				{Addr: "127.0.0.2:16267", GamePort: 16267, SteamID: "90268762793969688", Name: "Super Server", AppID: 108600, GameDir: "zomboid", Version: "1.0.0.0", Product: "zomboid", Region: -1, Players: 0, MaxPlayers: 10, Bots: 0, Map: "vehicle_interior;SecretZ_v4;InG", Secure: false, Dedicated: true, OS: "w", GameType: ""}},
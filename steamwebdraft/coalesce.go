@@ -0,0 +1,102 @@
+package steamweb
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// flight is an in-flight request shared by identical calls.
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+
+	// state collects requests of the shared call, they are reported
+	// by every waiting call.
+	state callState
+}
+
+// flightGroup deduplicates identical concurrent requests, so they share
+// one round trip and its result.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do calls fn once for all concurrent callers with the same key. The shared
+// call is canceled only when every caller waiting for it is gone.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+
+	f, ok := g.flights[key]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		flightCtx, cancel := context.WithCancel(context.WithValue(context.WithoutCancel(ctx), callStateKey{}, &f.state))
+		f.cancel = cancel
+		g.flights[key] = f
+
+		go func() {
+			f.body, f.err = fn(flightCtx)
+
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+
+			cancel()
+			close(f.done)
+		}()
+	}
+
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if state, ok := ctx.Value(callStateKey{}).(*callState); ok {
+			state.merge(&f.state)
+		}
+
+		return f.body, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+
+		// Abandoned flight must not be joined by new callers.
+		if f.waiters == 0 {
+			f.cancel()
+
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+}
+
+// coalesce sends the request sharing the round trip with identical
+// in-flight GET requests.
+func (c *Client) coalesce(ctx context.Context, r *Request) ([]byte, error) {
+	if c.config.DisableCoalescing || r.httpMethod() != http.MethodGet {
+		return c.sendRequest(ctx, r)
+	}
+
+	key, err := requestKey(c.config.URL, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.flights.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return c.sendRequest(ctx, r)
+	})
+}
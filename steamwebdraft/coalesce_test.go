@@ -0,0 +1,191 @@
+package steamweb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitWaiters waits until n callers join the in-flight request.
+func waitWaiters(t *testing.T, client *Client, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		client.flights.mu.Lock()
+		defer client.flights.mu.Unlock()
+
+		waiters := 0
+		for _, f := range client.flights.flights {
+			waiters += f.waiters
+		}
+
+		return waiters == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestClient_Coalescing(t *testing.T) {
+	const callers = 10
+
+	var requests atomic.Int32
+
+	release := make(chan struct{})
+	status := http.StatusOK
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+
+		w.WriteHeader(status)
+		w.Write([]byte(`{"response":{"servers":[{"addr":"127.0.0.1:27015","players":1}]}}`))
+	}))
+	defer ts.Close()
+
+	run := func(t *testing.T, client *Client, filter *GetServerListFilter) ([][]Server, []error) {
+		t.Helper()
+
+		requests.Store(0)
+
+		release = make(chan struct{})
+
+		var wg sync.WaitGroup
+
+		servers := make([][]Server, callers)
+		errs := make([]error, callers)
+
+		for i := range callers {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				servers[i], errs[i] = client.GetServerList(filter)
+			}()
+		}
+
+		if !client.config.DisableCoalescing {
+			waitWaiters(t, client, callers)
+		}
+
+		close(release)
+		wg.Wait()
+
+		return servers, errs
+	}
+
+	t.Run("shared result", func(t *testing.T) {
+		client := NewClient(newConfig(ts.URL))
+
+		servers, errs := run(t, client, &GetServerListFilter{AppID: 108600})
+
+		assert.Equal(t, int32(1), requests.Load())
+
+		for i := range callers {
+			require.NoError(t, errs[i])
+			assert.Equal(t, []Server{{Addr: "127.0.0.1:27015", Players: 1}}, servers[i])
+		}
+	})
+
+	t.Run("shared stats", func(t *testing.T) {
+		recorder := &callRecorder{}
+		client := NewClient(newConfig(ts.URL), WithInstrumenter(recorder))
+
+		_, errs := run(t, client, &GetServerListFilter{AppID: 108600})
+
+		assert.Equal(t, int32(1), requests.Load())
+		require.Len(t, recorder.stats, callers)
+
+		for i := range callers {
+			require.NoError(t, errs[i])
+			assert.Equal(t, CallStats{StatusCode: http.StatusOK, Attempts: 1, Items: 1}, recorder.stats[i])
+		}
+	})
+
+	t.Run("shared error", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		defer func() { status = http.StatusOK }()
+
+		client := NewClient(newConfig(ts.URL))
+
+		_, errs := run(t, client, &GetServerListFilter{AppID: 108600})
+
+		assert.Equal(t, int32(1), requests.Load())
+
+		for i := range callers {
+			assert.ErrorIs(t, errs[i], ErrWrongStatusCode)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := newConfig(ts.URL)
+		cfg.DisableCoalescing = true
+
+		client := NewClient(cfg)
+
+		_, errs := run(t, client, &GetServerListFilter{AppID: 108600})
+
+		assert.Equal(t, int32(callers), requests.Load())
+
+		for i := range callers {
+			require.NoError(t, errs[i])
+		}
+	})
+}
+
+func TestFlightGroup_Cancel(t *testing.T) {
+	var group flightGroup
+
+	started := make(chan struct{})
+	finished := make(chan error, 1)
+
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		<-ctx.Done()
+		finished <- ctx.Err()
+
+		return nil, ctx.Err()
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+
+	results := make(chan error, 2)
+
+	go func() {
+		_, err := group.do(first, "key", fn)
+		results <- err
+	}()
+
+	<-started
+
+	go func() {
+		_, err := group.do(second, "key", fn)
+		results <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		group.mu.Lock()
+		defer group.mu.Unlock()
+
+		return group.flights["key"].waiters == 2
+	}, time.Second, time.Millisecond)
+
+	cancelFirst()
+	require.ErrorIs(t, <-results, context.Canceled)
+
+	select {
+	case <-finished:
+		t.Fatal("shared call must not be canceled while somebody waits for it")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	require.ErrorIs(t, <-results, context.Canceled)
+	require.ErrorIs(t, <-finished, context.Canceled)
+}
@@ -3,17 +3,27 @@ package steamweb
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 )
 
-var ErrConfigUndefinedParam = errors.New("config param is not defined")
+var (
+	ErrConfigUndefinedParam = errors.New("config param is not defined")
+	ErrConfigInvalidParam   = errors.New("config param is invalid")
+)
 
 const (
 	DefaultSteamURL            = "https://api.steampowered.com"
 	DefaultTimeout             = 10 * time.Second
 	DefaultTLSHandshakeTimeout = 5 * time.Second
 	DefaultDialerTimeout       = 5 * time.Second
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
 	DefaultLimit               = 50000
+	DefaultLogDumpLimit        = 4 << 10
+	DefaultMaxStreamSize       = 256 << 20
+	DefaultMaxResponseSize     = 128 << 20
 )
 
 type (
@@ -23,6 +33,33 @@ type (
 		// Key is access api key for Steam requests.
 		Key string `json:"key" yaml:"key"`
 
+		// Keys is a list of additional api keys. Together with Key they form
+		// a pool: the key is picked for every request, and keys rejected by
+		// Steam with 429 status are quarantined for KeyQuarantine. A key
+		// rejected with 403 status is quarantined only when another key
+		// succeeds, otherwise the method itself is forbidden.
+		Keys []string `json:"keys" yaml:"keys"`
+
+		// KeySelection is a strategy of picking a key from the pool,
+		// "round_robin" or "least_used".
+		//
+		// The default is "round_robin".
+		KeySelection string `json:"key_selection" yaml:"key_selection"`
+
+		// KeyQuarantine is a time a rejected key is not used. A single key is
+		// never quarantined, only the rejected request fails.
+		//
+		// The default is 5 minutes.
+		KeyQuarantine time.Duration `json:"key_quarantine" yaml:"key_quarantine"`
+
+		// KeyDailyLimit is a number of calls per key per UTC day after which
+		// the key is not used until the next day. Zero means no limit.
+		KeyDailyLimit int `json:"key_daily_limit" yaml:"key_daily_limit"`
+
+		// KeyInHeader sends api key in x-webapi-key header instead of
+		// the key query parameter, so it never appears in URLs.
+		KeyInHeader bool `json:"key_in_header" yaml:"key_in_header"`
+
 		// URL is a Steam Web API url string.
 		URL string `json:"url" yaml:"url"`
 
@@ -36,19 +73,156 @@ type (
 		Timeout time.Duration `json:"timeout" yaml:"timeout"`
 
 		// Transport is a configuration settings for the implementation
-		// of RoundTripper that supports Openapi, HTTPS, and Openapi proxies.
-		Transport struct {
-			// A Dialer contains options for connecting to an address.
-			Dialer Dialer `json:"dialer" yaml:"dialer"`
+		// of RoundTripper that supports HTTP, HTTPS, and HTTP proxies.
+		Transport Transport `json:"transport" yaml:"transport"`
+
+		// DisableCoalescing turns off sharing of one round trip between
+		// identical concurrent GET requests. Requests of a shared round trip
+		// are reported in CallStats of every call waiting for it.
+		DisableCoalescing bool `json:"disable_coalescing" yaml:"disable_coalescing"`
+
+		// Cache configures caching of GET responses, it is disabled by default.
+		Cache CacheConfig `json:"cache" yaml:"cache"`
 
-			// TLSHandshakeTimeout specifies the maximum amount of time waiting to
-			// wait for a TLS handshake. Zero means no timeout.
-			TLSHandshakeTimeout time.Duration `json:"tls_handshake_timeout" yaml:"tls_handshake_timeout"`
-		} `json:"transport" yaml:"transport"`
+		// MaxResponseSize limits size of decoded response bodies read into
+		// memory. Larger responses fail with ResponseTooLargeError.
+		// Negative value disables the limit.
+		//
+		// The default is 128 MiB, a GetServerList response of DefaultLimit
+		// servers with long names and tags takes about 50 MiB.
+		MaxResponseSize int64 `json:"max_response_size" yaml:"max_response_size"`
+
+		// MaxStreamSize limits size of response bodies decoded by
+		// StreamServerList. Negative value disables the limit.
+		//
+		// The default is 256 MiB.
+		MaxStreamSize int64 `json:"max_stream_size" yaml:"max_stream_size"`
+
+		// Log configures logging of requests, nothing is logged without Logger.
+		Log LogConfig `json:"log" yaml:"log"`
 
 		Limit int `json:"limit" yaml:"limit"`
 
+		// DefaultServerNames are patterns of default server names removed
+		// by NoDefaultServers filter for any app. A pattern is an exact name,
+		// a glob with * and ? wildcards or a regexp wrapped in slashes, e.g.
+		// "/^my pz server \d+$/". Matching ignores case.
 		DefaultServerNames []string `json:"default_server_names" yaml:"default_server_names"`
+
+		// AppDefaultServerNames are patterns of default server names keyed by
+		// AppID. They replace entries of DefaultServerNamesByApp catalogue,
+		// an empty list turns the catalogue entry off.
+		AppDefaultServerNames map[int][]string `json:"app_default_server_names" yaml:"app_default_server_names"`
+
+		// AppUnsupportedFilters are filter params which Steam ignores keyed by
+		// AppID, e.g. "map". They replace entries of UnsupportedFiltersByApp
+		// table, an empty list turns the table entry off.
+		AppUnsupportedFilters map[int][]string `json:"app_unsupported_filters" yaml:"app_unsupported_filters"`
+	}
+
+	Transport struct {
+		// A Dialer contains options for connecting to an address.
+		Dialer Dialer `json:"dialer" yaml:"dialer"`
+
+		// TLSHandshakeTimeout specifies the maximum amount of time waiting to
+		// wait for a TLS handshake. Zero means no timeout.
+		TLSHandshakeTimeout time.Duration `json:"tls_handshake_timeout" yaml:"tls_handshake_timeout"`
+
+		// Proxy is a proxy URL used for all requests. Supported schemes are
+		// http, https, socks5 and socks5h. Empty means no proxy.
+		Proxy string `json:"proxy" yaml:"proxy"`
+
+		// MaxIdleConns controls the maximum number of idle (keep-alive)
+		// connections across all hosts.
+		//
+		// The default is 100.
+		MaxIdleConns int `json:"max_idle_conns" yaml:"max_idle_conns"`
+
+		// MaxIdleConnsPerHost controls the maximum idle (keep-alive)
+		// connections to keep per-host.
+		//
+		// The default is 10.
+		MaxIdleConnsPerHost int `json:"max_idle_conns_per_host" yaml:"max_idle_conns_per_host"`
+
+		// IdleConnTimeout is the maximum amount of time an idle
+		// (keep-alive) connection will remain idle before closing itself.
+		//
+		// The default is 90 seconds.
+		IdleConnTimeout time.Duration `json:"idle_conn_timeout" yaml:"idle_conn_timeout"`
+
+		// ResponseHeaderTimeout specifies the amount of time to wait for
+		// a server's response headers after fully writing the request.
+		// Zero means no timeout.
+		ResponseHeaderTimeout time.Duration `json:"response_header_timeout" yaml:"response_header_timeout"`
+
+		// EnableHTTP2 negotiates HTTP/2 with servers supporting it,
+		// HTTP/1.1 is used by default.
+		EnableHTTP2 bool `json:"enable_http2" yaml:"enable_http2"`
+
+		// DisableCompression stops requesting gzip, deflate and brotli
+		// compressed responses.
+		DisableCompression bool `json:"disable_compression" yaml:"disable_compression"`
+
+		// TLS contains paths to custom certificates.
+		TLS TLS `json:"tls" yaml:"tls"`
+	}
+
+	CacheConfig struct {
+		// Enabled turns on caching of GET responses. Built-in LRUCache is used
+		// unless another Cache is passed with WithCache.
+		Enabled bool `json:"enabled" yaml:"enabled"`
+
+		// TTL is a time to live of cached responses.
+		//
+		// The default is 1 minute.
+		TTL time.Duration `json:"ttl" yaml:"ttl"`
+
+		// TTLs overrides TTL for methods keyed by "Interface/Method",
+		// e.g. "ISteamUser/GetPlayerBans". Zero TTL disables caching of the method.
+		TTLs map[string]time.Duration `json:"ttls" yaml:"ttls"`
+
+		// StaleWhileRevalidate is a time after expiration during which the
+		// stale response is returned while it is refreshed in background.
+		// Zero means that expired responses are never returned.
+		StaleWhileRevalidate time.Duration `json:"stale_while_revalidate" yaml:"stale_while_revalidate"`
+
+		// MaxEntries limits a number of responses in the built-in cache.
+		//
+		// The default is 1000.
+		MaxEntries int `json:"max_entries" yaml:"max_entries"`
+
+		// MaxBytes limits total size of responses in the built-in cache.
+		//
+		// The default is 64 MiB.
+		MaxBytes int64 `json:"max_bytes" yaml:"max_bytes"`
+	}
+
+	LogConfig struct {
+		// Logger receives every request sent to Steam at debug level,
+		// rejected keys and failed requests at warn level. Api keys are
+		// redacted. It can also be set with WithLogger.
+		Logger *slog.Logger `json:"-" yaml:"-"`
+
+		// DumpBodies additionally logs request params and response bodies
+		// at debug level for troubleshooting. Params other than api keys
+		// are logged as is.
+		DumpBodies bool `json:"dump_bodies" yaml:"dump_bodies"`
+
+		// DumpLimit is a maximum number of logged bytes of each body.
+		//
+		// The default is 4 KiB.
+		DumpLimit int `json:"dump_limit" yaml:"dump_limit"`
+	}
+
+	TLS struct {
+		// CAFile is a path to PEM encoded CA bundle used instead of
+		// the system roots to verify the server certificate.
+		CAFile string `json:"ca_file" yaml:"ca_file"`
+
+		// CertFile and KeyFile are paths to PEM encoded client certificate
+		// and its private key. Both must be set to enable mutual TLS.
+		CertFile string `json:"cert_file" yaml:"cert_file"`
+		KeyFile  string `json:"key_file" yaml:"key_file"`
 	}
 
 	Dialer struct {
@@ -85,21 +259,59 @@ type (
 	}
 )
 
+// Validate checks that required params are set and valid.
+// All found problems are reported at once joined with errors.Join.
 func (cfg *Config) Validate() error {
 	// Do not validate config for disabled client.
 	if cfg.Disabled {
 		return nil
 	}
 
-	if cfg.Key == "" {
-		return fmt.Errorf("%w: %s", ErrConfigUndefinedParam, "key")
+	var errs []error
+
+	if cfg.Key == "" && len(cfg.Keys) == 0 {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrConfigUndefinedParam, "key"))
+	}
+
+	switch cfg.KeySelection {
+	case "", KeySelectionRoundRobin, KeySelectionLeastUsed:
+	default:
+		errs = append(errs, fmt.Errorf("%w: %s: unknown strategy %q", ErrConfigInvalidParam, "key_selection", cfg.KeySelection))
+	}
+
+	if cfg.KeyQuarantine < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "key_quarantine"))
+	}
+
+	if cfg.KeyDailyLimit < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "key_daily_limit"))
 	}
 
 	if cfg.URL == "" {
-		return fmt.Errorf("%w: %s", ErrConfigUndefinedParam, "url")
+		errs = append(errs, fmt.Errorf("%w: %s", ErrConfigUndefinedParam, "url"))
+	}
+
+	if cfg.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "timeout"))
+	}
+
+	if cfg.Limit < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "limit"))
+	}
+
+	if cfg.Cache.TTL < 0 || cfg.Cache.StaleWhileRevalidate < 0 || cfg.Cache.MaxEntries < 0 || cfg.Cache.MaxBytes < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "cache"))
+	}
+
+	if cfg.Log.DumpLimit < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "log.dump_limit"))
 	}
 
-	return nil
+	errs = append(errs, validateNamePatterns(cfg)...)
+	errs = append(errs, validateUnsupportedFilters(cfg)...)
+	errs = append(errs, cfg.Transport.Validate())
+
+	return errors.Join(errs...)
 }
 
 func (cfg *Config) SetDefaults() {
@@ -111,12 +323,38 @@ func (cfg *Config) SetDefaults() {
 		cfg.Timeout = DefaultTimeout
 	}
 
-	if cfg.Transport.TLSHandshakeTimeout == 0 {
-		cfg.Transport.TLSHandshakeTimeout = DefaultTLSHandshakeTimeout
+	if cfg.KeySelection == "" {
+		cfg.KeySelection = KeySelectionRoundRobin
+	}
+
+	if cfg.KeyQuarantine == 0 {
+		cfg.KeyQuarantine = DefaultKeyQuarantine
+	}
+
+	cfg.Transport.SetDefaults()
+
+	if cfg.Cache.TTL == 0 {
+		cfg.Cache.TTL = DefaultCacheTTL
+	}
+
+	if cfg.Cache.MaxEntries == 0 {
+		cfg.Cache.MaxEntries = DefaultCacheMaxEntries
+	}
+
+	if cfg.Cache.MaxBytes == 0 {
+		cfg.Cache.MaxBytes = DefaultCacheMaxBytes
+	}
+
+	if cfg.MaxResponseSize == 0 {
+		cfg.MaxResponseSize = DefaultMaxResponseSize
+	}
+
+	if cfg.MaxStreamSize == 0 {
+		cfg.MaxStreamSize = DefaultMaxStreamSize
 	}
 
-	if cfg.Transport.Dialer.Timeout == 0 {
-		cfg.Transport.Dialer.Timeout = DefaultDialerTimeout
+	if cfg.Log.DumpLimit == 0 {
+		cfg.Log.DumpLimit = DefaultLogDumpLimit
 	}
 
 	if cfg.Limit == 0 {
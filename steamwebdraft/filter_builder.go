@@ -0,0 +1,254 @@
+package steamweb
+
+// ServerFilterBuilder builds GetServerListFilter with chained calls:
+//
+//	filter, err := steamweb.NewServerFilter(108600).
+//		Dedicated().
+//		Secure().
+//		NameMatch("PvP").
+//		NotOr(steamweb.FilterCondition("map", "Muldraugh, KY")).
+//		Build()
+type ServerFilterBuilder struct {
+	filter GetServerListFilter
+}
+
+// NewServerFilter starts a filter of servers running game appID.
+func NewServerFilter(appID int) *ServerFilterBuilder {
+	return &ServerFilterBuilder{filter: GetServerListFilter{AppID: appID}}
+}
+
+// Build validates and returns the filter. The builder may be used further,
+// the returned filter does not change.
+func (b *ServerFilterBuilder) Build() (*GetServerListFilter, error) {
+	filter := b.Filter()
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// Filter returns a copy of the filter without validation.
+func (b *ServerFilterBuilder) Filter() *GetServerListFilter {
+	filter := b.filter
+
+	filter.NotOr = cloneStrings(b.filter.NotOr)
+	filter.NotAnd = cloneStrings(b.filter.NotAnd)
+	filter.GameTypeTags = cloneStrings(b.filter.GameTypeTags)
+	filter.NotGameTypeTags = cloneStrings(b.filter.NotGameTypeTags)
+	filter.GameDataTags = cloneStrings(b.filter.GameDataTags)
+	filter.GameDataOrTags = cloneStrings(b.filter.GameDataOrTags)
+	filter.Regions = append([]Region(nil), b.filter.Regions...)
+	filter.Match = append([]ServerPredicate(nil), b.filter.Match...)
+	filter.Sort = append([]SortOrder(nil), b.filter.Sort...)
+
+	return &filter
+}
+
+func cloneStrings(values []string) []string {
+	return append([]string(nil), values...)
+}
+
+// NotOr adds conditions excluding servers matching any of them.
+func (b *ServerFilterBuilder) NotOr(conditions ...string) *ServerFilterBuilder {
+	b.filter.NotOr = append(b.filter.NotOr, conditions...)
+
+	return b
+}
+
+// NotAnd adds conditions excluding servers matching all of them.
+func (b *ServerFilterBuilder) NotAnd(conditions ...string) *ServerFilterBuilder {
+	b.filter.NotAnd = append(b.filter.NotAnd, conditions...)
+
+	return b
+}
+
+// Dedicated selects dedicated servers.
+func (b *ServerFilterBuilder) Dedicated() *ServerFilterBuilder {
+	b.filter.Dedicated = true
+
+	return b
+}
+
+// Secure selects servers using anti-cheat.
+func (b *ServerFilterBuilder) Secure() *ServerFilterBuilder {
+	b.filter.Secure = true
+
+	return b
+}
+
+// GameDir selects servers running the modification.
+func (b *ServerFilterBuilder) GameDir(dir string) *ServerFilterBuilder {
+	b.filter.GameDir = dir
+
+	return b
+}
+
+// Map selects servers running the map.
+func (b *ServerFilterBuilder) Map(name string) *ServerFilterBuilder {
+	b.filter.Map = name
+
+	return b
+}
+
+// Linux selects servers running on Linux.
+func (b *ServerFilterBuilder) Linux() *ServerFilterBuilder {
+	b.filter.Linux = true
+
+	return b
+}
+
+// NoPassword selects servers without a password.
+func (b *ServerFilterBuilder) NoPassword() *ServerFilterBuilder {
+	b.filter.NoPassword = true
+
+	return b
+}
+
+// NotEmpty selects servers with players.
+func (b *ServerFilterBuilder) NotEmpty() *ServerFilterBuilder {
+	b.filter.NotEmpty = true
+
+	return b
+}
+
+// NotFull selects servers with free slots.
+func (b *ServerFilterBuilder) NotFull() *ServerFilterBuilder {
+	b.filter.NotFull = true
+
+	return b
+}
+
+// Proxy selects spectator proxies.
+func (b *ServerFilterBuilder) Proxy() *ServerFilterBuilder {
+	b.filter.Proxy = true
+
+	return b
+}
+
+// NotAppID excludes servers running game appID.
+func (b *ServerFilterBuilder) NotAppID(appID int) *ServerFilterBuilder {
+	b.filter.NotAppID = appID
+
+	return b
+}
+
+// NoPlayers selects empty servers.
+func (b *ServerFilterBuilder) NoPlayers() *ServerFilterBuilder {
+	b.filter.NoPlayers = true
+
+	return b
+}
+
+// Whitelisted selects whitelisted servers.
+func (b *ServerFilterBuilder) Whitelisted() *ServerFilterBuilder {
+	b.filter.Whitelisted = true
+
+	return b
+}
+
+// GameTypeTags selects servers with all of the tags.
+func (b *ServerFilterBuilder) GameTypeTags(tags ...string) *ServerFilterBuilder {
+	b.filter.GameTypeTags = append(b.filter.GameTypeTags, tags...)
+
+	return b
+}
+
+// NotGameTypeTags excludes servers with any of the tags.
+func (b *ServerFilterBuilder) NotGameTypeTags(tags ...string) *ServerFilterBuilder {
+	b.filter.NotGameTypeTags = append(b.filter.NotGameTypeTags, tags...)
+
+	return b
+}
+
+// GameDataTags selects servers with all of the hidden tags.
+func (b *ServerFilterBuilder) GameDataTags(tags ...string) *ServerFilterBuilder {
+	b.filter.GameDataTags = append(b.filter.GameDataTags, tags...)
+
+	return b
+}
+
+// GameDataOrTags selects servers with any of the hidden tags.
+func (b *ServerFilterBuilder) GameDataOrTags(tags ...string) *ServerFilterBuilder {
+	b.filter.GameDataOrTags = append(b.filter.GameDataOrTags, tags...)
+
+	return b
+}
+
+// NameMatch selects servers with the name containing the pattern.
+func (b *ServerFilterBuilder) NameMatch(pattern string) *ServerFilterBuilder {
+	b.filter.NameMatch = pattern
+
+	return b
+}
+
+// VersionMatch selects servers running the version, * is a wildcard.
+func (b *ServerFilterBuilder) VersionMatch(pattern string) *ServerFilterBuilder {
+	b.filter.VersionMatch = pattern
+
+	return b
+}
+
+// CollapseAddrHash returns one server per IP address.
+func (b *ServerFilterBuilder) CollapseAddrHash() *ServerFilterBuilder {
+	b.filter.CollapseAddrHash = true
+
+	return b
+}
+
+// GameAddr selects servers on the IP address, the port is optional.
+func (b *ServerFilterBuilder) GameAddr(addr string) *ServerFilterBuilder {
+	b.filter.GameAddr = addr
+
+	return b
+}
+
+// NoHidden excludes servers with the "hidden" tag.
+func (b *ServerFilterBuilder) NoHidden() *ServerFilterBuilder {
+	b.filter.NoHidden = true
+
+	return b
+}
+
+// NoDefaultServers excludes servers with default names.
+func (b *ServerFilterBuilder) NoDefaultServers() *ServerFilterBuilder {
+	b.filter.NoDefaultServers = true
+
+	return b
+}
+
+// Regions selects servers located in one of the regions.
+func (b *ServerFilterBuilder) Regions(regions ...Region) *ServerFilterBuilder {
+	b.filter.Regions = append(b.filter.Regions, regions...)
+
+	return b
+}
+
+// Strict fails requests when a param is ignored for the app.
+func (b *ServerFilterBuilder) Strict() *ServerFilterBuilder {
+	b.filter.Strict = true
+
+	return b
+}
+
+// Match adds client-side predicates.
+func (b *ServerFilterBuilder) Match(predicates ...ServerPredicate) *ServerFilterBuilder {
+	b.filter.Match = append(b.filter.Match, predicates...)
+
+	return b
+}
+
+// Sort adds sort orders.
+func (b *ServerFilterBuilder) Sort(orders ...SortOrder) *ServerFilterBuilder {
+	b.filter.Sort = append(b.filter.Sort, orders...)
+
+	return b
+}
+
+// Limit limits the number of requested servers.
+func (b *ServerFilterBuilder) Limit(limit int) *ServerFilterBuilder {
+	b.filter.Limit = limit
+
+	return b
+}
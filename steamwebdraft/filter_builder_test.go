@@ -0,0 +1,164 @@
+package steamweb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerFilterBuilder(t *testing.T) {
+	builder := NewServerFilter(108600).
+		Dedicated().
+		Secure().
+		NameMatch("PvP").
+		VersionMatch("41.*").
+		CollapseAddrHash().
+		GameAddr("127.0.0.1").
+		NotOr(FilterCondition("map", "Muldraugh, KY"), FilterCondition("gametype", "hidden")).
+		NotAnd(FilterCondition("linux", "1"), FilterCondition("secure", "0")).
+		Limit(100)
+
+	filter, err := builder.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, `\appid\108600\dedicated\1\secure\1\name_match\*PvP*\version_match\41.*\collapse_addr_hash\1`+
+		`\gameaddr\127.0.0.1\nor\2\map\Muldraugh, KY\gametype\hidden\nand\2\linux\1\secure\0`, filter.String())
+	assert.Equal(t, 100, filter.Limit)
+
+	// Built filter does not change with the builder.
+	builder.NotOr(FilterCondition("map", "Riverside, KY")).NoPlayers()
+	assert.Len(t, filter.NotOr, 2)
+	assert.False(t, filter.NoPlayers)
+}
+
+func TestGetServerListFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *ServerFilterBuilder
+		want   []string
+		errs   []error
+	}{
+		{
+			name:   "valid",
+			filter: NewServerFilter(108600).NotFull().GameTypeTags("pvp").GameAddr("127.0.0.1:16261").Regions(RegionEurope),
+		},
+		{
+			name:   "appid",
+			filter: NewServerFilter(0),
+			want:   []string{"param is required: appid"},
+			errs:   []error{ErrRequiredParam},
+		},
+		{
+			name:   "backslash",
+			filter: NewServerFilter(108600).NameMatch(`My\Server`).Map("map\n").GameDir("zombo\u0085id"),
+			want: []string{
+				`param is invalid: gamedir: forbidden character '\u0085'`,
+				`param is invalid: map: forbidden character '\n'`,
+				`param is invalid: name_match: forbidden character '\\'`,
+			},
+			errs: []error{ErrInvalidParam},
+		},
+		{
+			name:   "conflicts",
+			filter: NewServerFilter(108600).NotEmpty().NoPlayers().NotAppID(108600).GameTypeTags("pvp").NotGameTypeTags("pvp"),
+			want: []string{
+				"params are conflicting: empty: conflicts with noplayers",
+				"params are conflicting: napp: conflicts with appid",
+				"params are conflicting: not_gametype: conflicts with gametype",
+			},
+			errs: []error{ErrConflictingParams},
+		},
+		{
+			name:   "limit",
+			filter: NewServerFilter(108600).Limit(-1),
+			want:   []string{"param is invalid: limit: negative value -1"},
+			errs:   []error{ErrInvalidParam},
+		},
+		{
+			name: "values",
+			filter: NewServerFilter(-1).GameAddr("localhost").GameTypeTags("a;b", " ").
+				NotOr("map").Regions(42).Sort(SortOrder{Key: "rank"}),
+			want: []string{
+				"param is invalid: appid: negative value -1",
+				`param is invalid: gameaddr: "localhost" is not an ip or ip:port`,
+				`param is invalid: gametype: tag "a;b" contains a separator`,
+				"param is invalid: gametype: empty tag",
+				`param is invalid: nor: condition "map" is not \key\value`,
+				"param is invalid: region: unknown region 42",
+				`param is invalid: sort: unknown key "rank"`,
+			},
+			errs: []error{ErrInvalidParam},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := test.filter.Build()
+			if len(test.want) == 0 {
+				require.NoError(t, err)
+				assert.NotNil(t, filter)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, filter)
+
+			var messages []string
+
+			for _, err := range err.(interface{ Unwrap() []error }).Unwrap() { //nolint:errorlint // Joined errors.
+				var filterErr *FilterError
+				require.ErrorAs(t, err, &filterErr)
+
+				messages = append(messages, err.Error())
+			}
+
+			assert.Equal(t, test.want, messages)
+
+			for _, target := range test.errs {
+				require.ErrorIs(t, err, target)
+			}
+		})
+	}
+}
+
+func TestClient_GetServerList_InvalidFilter(t *testing.T) {
+	client := NewClient(newConfig("http://127.0.0.1:0"))
+
+	_, err := client.GetServerList(&GetServerListFilter{})
+	require.ErrorIs(t, err, ErrRequiredParam)
+
+	var filterErr *FilterError
+	require.ErrorAs(t, err, &filterErr)
+	assert.Equal(t, "appid", filterErr.Param)
+
+	for _, err := range client.StreamServerList(context.Background(), &GetServerListFilter{AppID: 108600, NameMatch: `\`}) {
+		require.ErrorIs(t, err, ErrInvalidParam)
+		require.False(t, errors.Is(err, ErrRequiredParam))
+	}
+}
+
+func TestGetServerListFilter_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter GetServerListFilter
+		want   string
+	}{
+		{name: "nor", filter: GetServerListFilter{NotOr: []string{FilterCondition("map", "a"), FilterCondition("map", "b")}}, want: `\nor\2\map\a\map\b`},
+		{name: "nand", filter: GetServerListFilter{NotAnd: []string{FilterCondition("linux", "1")}}, want: `\nand\1\linux\1`},
+		{name: "version_match", filter: GetServerListFilter{VersionMatch: "41.78.*"}, want: `\version_match\41.78.*`},
+		{name: "collapse_addr_hash", filter: GetServerListFilter{CollapseAddrHash: true}, want: `\collapse_addr_hash\1`},
+		{name: "gameaddr", filter: GetServerListFilter{GameAddr: "127.0.0.1:16261"}, want: `\gameaddr\127.0.0.1:16261`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.filter.AppID = 108600
+
+			assert.Equal(t, `\appid\108600`+test.want, test.filter.String())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"time"
+)
+
+type (
+	// Value returns a number of a sample to aggregate.
+	Value func(sample *Sample) int
+
+	// Point aggregates samples taken in [Time, Time+step).
+	Point struct {
+		Time  time.Time `json:"time"`
+		Count int       `json:"count"`
+		Min   int       `json:"min"`
+		Max   int       `json:"max"`
+		Avg   float64   `json:"avg"`
+	}
+)
+
+// Values of samples.
+var (
+	Players    Value = func(sample *Sample) int { return sample.Players }
+	MaxPlayers Value = func(sample *Sample) int { return sample.MaxPlayers }
+	Bots       Value = func(sample *Sample) int { return sample.Bots }
+)
+
+// Summarize aggregates the value of all samples into a point with the time
+// of the first sample. Samples of several servers are aggregated together,
+// so query a single server to chart it.
+func Summarize(samples []Sample, value Value) Point {
+	var point Point
+
+	for i := range samples {
+		point.add(&samples[i], value)
+	}
+
+	if len(samples) != 0 {
+		point.Time = samples[0].Time
+	}
+
+	point.finish()
+
+	return point
+}
+
+// Downsample aggregates the value of samples ordered by time into points
+// of step duration aligned to step since the zero time. Steps without
+// samples are omitted.
+func Downsample(samples []Sample, step time.Duration, value Value) []Point {
+	var points []Point
+
+	for i := range samples {
+		start := samples[i].Time.Truncate(step)
+
+		if len(points) == 0 || !points[len(points)-1].Time.Equal(start) {
+			if len(points) != 0 {
+				points[len(points)-1].finish()
+			}
+
+			points = append(points, Point{Time: start})
+		}
+
+		points[len(points)-1].add(&samples[i], value)
+	}
+
+	if len(points) != 0 {
+		points[len(points)-1].finish()
+	}
+
+	return points
+}
+
+// add accumulates the sum in Avg until finish is called.
+func (p *Point) add(sample *Sample, value Value) {
+	v := value(sample)
+
+	if p.Count == 0 || v < p.Min {
+		p.Min = v
+	}
+
+	if p.Count == 0 || v > p.Max {
+		p.Max = v
+	}
+
+	p.Count++
+	p.Avg += float64(v)
+}
+
+func (p *Point) finish() {
+	if p.Count != 0 {
+		p.Avg /= float64(p.Count)
+	}
+}
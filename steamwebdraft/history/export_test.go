@@ -0,0 +1,9 @@
+package history
+
+// SetRename replaces the function renaming files in Compact.
+func SetRename(fn func(oldpath, newpath string) error) (restore func()) {
+	prev := rename
+	rename = fn
+
+	return func() { rename = prev }
+}
@@ -0,0 +1,255 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrCorruptedStore = errors.New("corrupted history store")
+
+// rename replaces the file by Compact, tests make it fail.
+var rename = os.Rename
+
+// FileStore is Store keeping samples in a file of JSON lines. Samples are
+// only appended, Compact rewrites the file to drop old samples.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+var _ Store = (*FileStore)(nil)
+
+// OpenFileStore opens or creates the file store. A partial line left
+// by an interrupted write is removed.
+func OpenFileStore(path string) (*FileStore, error) {
+	file, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := truncatePartialLine(path); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return &FileStore{path: path, file: file}, nil
+}
+
+// truncatePartialLine cuts the file after its last line break.
+func truncatePartialLine(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	const blockSize = 4096
+
+	block := make([]byte, blockSize)
+
+	for end := info.Size(); end > 0; {
+		start := max(end-blockSize, 0)
+
+		n, err := file.ReadAt(block[:end-start], start)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		if i := bytes.LastIndexByte(block[:n], '\n'); i >= 0 {
+			if size := start + int64(i) + 1; size != info.Size() {
+				return file.Truncate(size)
+			}
+
+			return nil
+		}
+
+		end = start
+	}
+
+	return file.Truncate(0)
+}
+
+func openAppend(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:mnd // File permissions.
+}
+
+// Append implements Store. Samples are written with a single write, so
+// a crash leaves at most one partial line, which is skipped when read.
+func (s *FileStore) Append(_ context.Context, samples []Sample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	for i := range samples {
+		if err := encoder.Encode(&samples[i]); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.file.Write(buf.Bytes())
+
+	return err
+}
+
+// Query implements Store.
+func (s *FileStore) Query(ctx context.Context, query Query) ([]Sample, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var samples []Sample
+
+	err := s.read(ctx, func(sample *Sample) {
+		if query.Match(sample) {
+			samples = append(samples, *sample)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortByTime(samples)
+
+	return samples, nil
+}
+
+// Compact rewrites the file keeping samples taken at or after retainFrom,
+// ordered by time and without duplicates of the same server and time.
+// The file is replaced atomically.
+func (s *FileStore) Compact(ctx context.Context, retainFrom time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var samples []Sample
+
+	err := s.read(ctx, func(sample *Sample) {
+		if !sample.Time.Before(retainFrom) {
+			samples = append(samples, *sample)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// Duplicates are adjacent once samples of the same time are ordered
+	// by address, the first appended one is kept.
+	slices.SortStableFunc(samples, func(a, b Sample) int {
+		if c := a.Time.Compare(b.Time); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Addr, b.Addr)
+	})
+	samples = slices.CompactFunc(samples, func(a, b Sample) bool {
+		return a.Time.Equal(b.Time) && a.Addr == b.Addr
+	})
+
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(temp.Name())
+
+	writer := bufio.NewWriter(temp)
+	encoder := json.NewEncoder(writer)
+
+	for i := range samples {
+		if err := encoder.Encode(&samples[i]); err != nil {
+			temp.Close()
+
+			return err
+		}
+	}
+
+	if err := errors.Join(writer.Flush(), temp.Sync(), temp.Close()); err != nil {
+		return err
+	}
+
+	// The store must stay writable whatever fails after the file is closed,
+	// the old file is reopened when it was not replaced.
+	closeErr := s.file.Close()
+
+	var renameErr error
+	if closeErr == nil {
+		renameErr = rename(temp.Name(), s.path)
+	}
+
+	file, err := openAppend(s.path)
+	if err == nil {
+		s.file = file
+	}
+
+	return errors.Join(closeErr, renameErr, err)
+}
+
+// Close closes the file.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// read calls fn for every sample of the file. The last line without
+// a line break is an interrupted write and it is skipped.
+func (s *FileStore) read(ctx context.Context, fn func(sample *Sample)) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for line := 1; ; line++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var sample Sample
+		if err := json.Unmarshal(data, &sample); err != nil {
+			return fmt.Errorf("%w: %s:%d: %w", ErrCorruptedStore, s.path, line, err)
+		}
+
+		fn(&sample)
+	}
+}
+
+func sortByTime(samples []Sample) {
+	slices.SortStableFunc(samples, func(a, b Sample) int {
+		return a.Time.Compare(b.Time)
+	})
+}
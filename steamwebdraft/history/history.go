@@ -0,0 +1,94 @@
+// Package history records server population samples taken from
+// GetServerList and aggregates them for charts:
+//
+//	store, err := history.OpenFileStore("servers.jsonl")
+//	if err != nil {
+//		return err
+//	}
+//	defer store.Close()
+//
+//	sampler := history.NewSampler(client, &steamweb.GetServerListFilter{AppID: 108600}, store)
+//	go sampler.Run(ctx)
+//
+//	samples, err := store.Query(ctx, history.Query{Addrs: []string{addr}, From: weekAgo})
+//	points := history.Downsample(samples, time.Hour, history.Players)
+package history
+
+import (
+	"context"
+	"time"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+)
+
+type (
+	// Sample is a state of a server at a time.
+	Sample struct {
+		Time       time.Time `json:"time"`
+		Addr       string    `json:"addr"`
+		SteamID    string    `json:"steamid,omitempty"`
+		Name       string    `json:"name,omitempty"`
+		Players    int       `json:"players"`
+		MaxPlayers int       `json:"max_players"`
+		Bots       int       `json:"bots"`
+		Map        string    `json:"map,omitempty"`
+		Version    string    `json:"version,omitempty"`
+	}
+
+	// Query selects samples.
+	Query struct {
+		// Addrs selects samples of servers with the addresses, empty means all servers.
+		Addrs []string
+
+		// From and To select samples taken in [From, To), zero means no bound.
+		From time.Time
+		To   time.Time
+	}
+
+	// Store keeps samples. Implementations must be safe for concurrent use.
+	Store interface {
+		// Append stores samples.
+		Append(ctx context.Context, samples []Sample) error
+
+		// Query returns samples ordered by time.
+		Query(ctx context.Context, query Query) ([]Sample, error)
+	}
+)
+
+// NewSample returns sample of the server taken at the time.
+func NewSample(at time.Time, server *steamweb.Server) Sample {
+	return Sample{
+		Time:       at,
+		Addr:       server.Addr,
+		SteamID:    server.SteamID,
+		Name:       server.Name,
+		Players:    server.Players,
+		MaxPlayers: server.MaxPlayers,
+		Bots:       server.Bots,
+		Map:        server.Map,
+		Version:    server.Version,
+	}
+}
+
+// Match reports whether the sample is selected by the query.
+func (q *Query) Match(sample *Sample) bool {
+	if !q.From.IsZero() && sample.Time.Before(q.From) {
+		return false
+	}
+
+	if !q.To.IsZero() && !sample.Time.Before(q.To) {
+		return false
+	}
+
+	if len(q.Addrs) == 0 {
+		return true
+	}
+
+	for _, addr := range q.Addrs {
+		if sample.Addr == addr {
+			return true
+		}
+	}
+
+	return false
+}
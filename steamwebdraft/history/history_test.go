@@ -0,0 +1,304 @@
+package history_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+	"github.com/gorcon/steamweb/steamwebdraft/history"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func sample(minutes int, addr string, players int) history.Sample {
+	return history.Sample{Time: start.Add(time.Duration(minutes) * time.Minute), Addr: addr, Players: players, MaxPlayers: 32}
+}
+
+func openStore(t *testing.T) (*history.FileStore, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := history.OpenFileStore(path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { store.Close() })
+
+	return store, path
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store, path := openStore(t)
+
+	require.NoError(t, store.Append(ctx, []history.Sample{sample(10, "a", 1), sample(10, "b", 2)}))
+	require.NoError(t, store.Append(ctx, []history.Sample{sample(0, "a", 3), sample(20, "a", 4)}))
+	require.NoError(t, store.Append(ctx, nil))
+
+	t.Run("query", func(t *testing.T) {
+		all, err := store.Query(ctx, history.Query{})
+		require.NoError(t, err)
+		assert.Equal(t, []history.Sample{sample(0, "a", 3), sample(10, "a", 1), sample(10, "b", 2), sample(20, "a", 4)}, all)
+
+		selected, err := store.Query(ctx, history.Query{Addrs: []string{"a"}, From: start.Add(10 * time.Minute), To: start.Add(20 * time.Minute)})
+		require.NoError(t, err)
+		assert.Equal(t, []history.Sample{sample(10, "a", 1)}, selected)
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		// Interrupted write leaves a partial line.
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = file.WriteString(`{"time":"2024-01-01T00:30:00Z","addr":"a","pla`)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		all, err := store.Query(ctx, history.Query{})
+		require.NoError(t, err)
+		assert.Len(t, all, 4)
+
+		require.NoError(t, store.Close())
+
+		store, err = history.OpenFileStore(path)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Append(ctx, []history.Sample{sample(30, "a", 5)}))
+
+		all, err = store.Query(ctx, history.Query{})
+		require.NoError(t, err)
+		assert.Len(t, all, 5)
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		require.NoError(t, store.Append(ctx, []history.Sample{sample(20, "a", 4)}))
+		require.NoError(t, store.Compact(ctx, start.Add(10*time.Minute)))
+
+		all, err := store.Query(ctx, history.Query{})
+		require.NoError(t, err)
+		assert.Equal(t, []history.Sample{sample(10, "a", 1), sample(10, "b", 2), sample(20, "a", 4), sample(30, "a", 5)}, all)
+
+		// The store is still writable after the file was replaced.
+		require.NoError(t, store.Append(ctx, []history.Sample{sample(40, "b", 6)}))
+
+		all, err = store.Query(ctx, history.Query{Addrs: []string{"b"}})
+		require.NoError(t, err)
+		assert.Equal(t, []history.Sample{sample(10, "b", 2), sample(40, "b", 6)}, all)
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
+
+func TestFileStore_CompactRenameFails(t *testing.T) {
+	ctx := context.Background()
+	store, path := openStore(t)
+
+	require.NoError(t, store.Append(ctx, []history.Sample{sample(0, "a", 1), sample(10, "a", 2)}))
+
+	errRename := errors.New("rename failed")
+	restore := history.SetRename(func(string, string) error { return errRename })
+	defer restore()
+
+	require.ErrorIs(t, store.Compact(ctx, start.Add(10*time.Minute)), errRename)
+
+	// The old file is kept and still writable.
+	require.NoError(t, store.Append(ctx, []history.Sample{sample(20, "a", 3)}))
+
+	all, err := store.Query(ctx, history.Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []history.Sample{sample(0, "a", 1), sample(10, "a", 2), sample(20, "a", 3)}, all)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestFileStore_CompactDuplicates(t *testing.T) {
+	ctx := context.Background()
+	store, _ := openStore(t)
+
+	require.NoError(t, store.Append(ctx, []history.Sample{sample(0, "a", 1), sample(0, "b", 2), sample(0, "a", 3)}))
+	require.NoError(t, store.Compact(ctx, start))
+
+	all, err := store.Query(ctx, history.Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []history.Sample{sample(0, "a", 1), sample(0, "b", 2)}, all)
+}
+
+func TestFileStore_Corrupted(t *testing.T) {
+	store, path := openStore(t)
+
+	require.NoError(t, os.WriteFile(path, []byte("{\"addr\":\"a\"}\nnot json\n"), 0o600))
+
+	_, err := store.Query(context.Background(), history.Query{})
+	require.ErrorIs(t, err, history.ErrCorruptedStore)
+	assert.Contains(t, err.Error(), ":2:")
+}
+
+func TestSummarize(t *testing.T) {
+	samples := []history.Sample{sample(0, "a", 3), sample(5, "a", 1), sample(10, "a", 8)}
+
+	assert.Equal(t, history.Point{Time: start, Count: 3, Min: 1, Max: 8, Avg: 4}, history.Summarize(samples, history.Players))
+	assert.Equal(t, history.Point{Time: start, Count: 3, Min: 32, Max: 32, Avg: 32}, history.Summarize(samples, history.MaxPlayers))
+	assert.Equal(t, history.Point{}, history.Summarize(nil, history.Players))
+}
+
+func TestDownsample(t *testing.T) {
+	samples := []history.Sample{
+		sample(0, "a", 2), sample(20, "a", 4), sample(59, "a", 9),
+		sample(60, "a", 1),
+		sample(200, "a", 5), sample(210, "a", 6),
+	}
+
+	assert.Equal(t, []history.Point{
+		{Time: start, Count: 3, Min: 2, Max: 9, Avg: 5},
+		{Time: start.Add(time.Hour), Count: 1, Min: 1, Max: 1, Avg: 1},
+		{Time: start.Add(3 * time.Hour), Count: 2, Min: 5, Max: 6, Avg: 5.5},
+	}, history.Downsample(samples, time.Hour, history.Players))
+
+	assert.Empty(t, history.Downsample(nil, time.Hour, history.Players))
+}
+
+type listerFunc func(ctx context.Context, filter *steamweb.GetServerListFilter) ([]steamweb.Server, error)
+
+func (fn listerFunc) GetServerListContext(ctx context.Context, filter *steamweb.GetServerListFilter) ([]steamweb.Server, error) {
+	return fn(ctx, filter)
+}
+
+// manualClock fires After channels when tick is called.
+type manualClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	after chan chan time.Time
+	waits []time.Duration
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.waits = append(c.waits, d)
+	c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.after <- ch
+
+	return ch
+}
+
+func (c *manualClock) tick(t *testing.T, d time.Duration) {
+	t.Helper()
+
+	select {
+	case ch := <-c.after:
+		c.mu.Lock()
+		c.now = c.now.Add(d)
+		c.mu.Unlock()
+
+		ch <- c.now
+	case <-time.After(time.Second):
+		t.Fatal("sampler does not wait for the clock")
+	}
+}
+
+func TestSampler(t *testing.T) {
+	errSteam := errors.New("steam is down")
+
+	var (
+		mu    sync.Mutex
+		calls int
+		errs  []error
+	)
+
+	lister := listerFunc(func(_ context.Context, filter *steamweb.GetServerListFilter) ([]steamweb.Server, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Equal(t, 108600, filter.AppID)
+
+		calls++
+		if calls == 2 {
+			return nil, errSteam
+		}
+
+		return []steamweb.Server{
+			{Addr: "a", SteamID: "1", Name: "Alpha", Players: calls, MaxPlayers: 32, Bots: 1, Map: "Muldraugh, KY", Version: "41.78"},
+		}, nil
+	})
+
+	store, _ := openStore(t)
+	clock := &manualClock{now: start, after: make(chan chan time.Time, 1)}
+
+	sampler := history.NewSampler(lister, &steamweb.GetServerListFilter{AppID: 108600}, store,
+		history.WithClock(clock), history.WithInterval(time.Minute),
+		history.WithErrorHandler(func(err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- sampler.Run(ctx)
+	}()
+
+	clock.tick(t, time.Minute)
+	clock.tick(t, time.Minute)
+	clock.tick(t, time.Minute)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	samples, err := store.Query(context.Background(), history.Query{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []history.Sample{
+		{Time: start, Addr: "a", SteamID: "1", Name: "Alpha", Players: 1, MaxPlayers: 32, Bots: 1, Map: "Muldraugh, KY", Version: "41.78"},
+		{Time: start.Add(2 * time.Minute), Addr: "a", SteamID: "1", Name: "Alpha", Players: 3, MaxPlayers: 32, Bots: 1, Map: "Muldraugh, KY", Version: "41.78"},
+	}, samples[:2])
+	assert.Equal(t, []error{errSteam}, errs)
+}
+
+func TestSampler_MinInterval(t *testing.T) {
+	lister := listerFunc(func(context.Context, *steamweb.GetServerListFilter) ([]steamweb.Server, error) {
+		return nil, nil
+	})
+
+	for _, interval := range []time.Duration{0, -time.Minute, time.Millisecond} {
+		store, _ := openStore(t)
+		clock := &manualClock{now: start, after: make(chan chan time.Time, 1)}
+		sampler := history.NewSampler(lister, &steamweb.GetServerListFilter{AppID: 108600}, store,
+			history.WithClock(clock), history.WithInterval(interval))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() {
+			done <- sampler.Run(ctx)
+		}()
+
+		clock.tick(t, steamweb.MinWatchInterval)
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+
+		clock.mu.Lock()
+		assert.Equal(t, steamweb.MinWatchInterval, clock.waits[0], interval)
+		clock.mu.Unlock()
+	}
+}
@@ -0,0 +1,97 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+)
+
+const DefaultInterval = 5 * time.Minute
+
+// Sampler periodically lists servers and appends their samples to Store.
+type Sampler struct {
+	lister   steamweb.ServerLister
+	filter   *steamweb.GetServerListFilter
+	store    Store
+	interval time.Duration
+	clock    steamweb.Clock
+	onError  func(err error)
+}
+
+// Option configures Sampler.
+type Option func(*Sampler)
+
+// WithInterval sets a time between samples. Intervals shorter than
+// steamweb.MinWatchInterval, including zero and negative ones, are raised to it.
+// The default is 5 minutes.
+func WithInterval(interval time.Duration) Option {
+	return func(s *Sampler) {
+		s.interval = max(interval, steamweb.MinWatchInterval)
+	}
+}
+
+// WithClock replaces the system clock.
+func WithClock(clock steamweb.Clock) Option {
+	return func(s *Sampler) {
+		s.clock = clock
+	}
+}
+
+// WithErrorHandler sets a function called when sampling fails,
+// errors are ignored by default.
+func WithErrorHandler(onError func(err error)) Option {
+	return func(s *Sampler) {
+		s.onError = onError
+	}
+}
+
+// NewSampler creates Sampler of servers matching the filter.
+func NewSampler(lister steamweb.ServerLister, filter *steamweb.GetServerListFilter, store Store, opts ...Option) *Sampler {
+	sampler := &Sampler{
+		lister:   lister,
+		filter:   filter,
+		store:    store,
+		interval: DefaultInterval,
+		clock:    steamweb.SystemClock{},
+		onError:  func(error) {},
+	}
+
+	for _, opt := range opts {
+		opt(sampler)
+	}
+
+	return sampler
+}
+
+// Sample lists servers once and stores their samples.
+func (s *Sampler) Sample(ctx context.Context) error {
+	servers, err := s.lister.GetServerListContext(ctx, s.filter)
+	if err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+	samples := make([]Sample, len(servers))
+
+	for i := range servers {
+		samples[i] = NewSample(now, &servers[i])
+	}
+
+	return s.store.Append(ctx, samples)
+}
+
+// Run samples servers until ctx is done and returns its error.
+func (s *Sampler) Run(ctx context.Context) error {
+	for {
+		if err := s.Sample(ctx); err != nil && ctx.Err() == nil {
+			s.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.clock.After(s.interval):
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package steamweb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// Error classes returned by ErrorClass.
+const (
+	ErrorClassNone     = ""
+	ErrorClassCanceled = "canceled"
+	ErrorClassTimeout  = "timeout"
+	ErrorClassStatus   = "status"
+	ErrorClassNoKeys   = "no_keys"
+	ErrorClassResponse = "response"
+	ErrorClassNetwork  = "network"
+	ErrorClassOther    = "other"
+)
+
+type (
+	// Instrumenter receives events about every HTTP request sent to Steam,
+	// including repeated requests with another api key. Responses served
+	// from the cache or shared with identical in-flight requests are not
+	// reported. Implementations must be safe for concurrent use.
+	Instrumenter interface {
+		// RequestStarted is called before the request is sent. Returned
+		// context is used for the request and passed to RequestFinished.
+		RequestStarted(ctx context.Context, info RequestInfo) context.Context
+
+		// RequestFinished is called when the response body is read or the
+		// request failed.
+		RequestFinished(ctx context.Context, info RequestInfo, stats RequestStats)
+	}
+
+	// RequestInfo describes HTTP request sent to Steam.
+	RequestInfo struct {
+		Interface  string
+		Method     string
+		Version    int
+		HTTPMethod string
+
+		// Attempt is a number of the request for the same call starting
+		// from 1. It is greater than 1 when previous key was rejected.
+		Attempt int
+
+		// Key is a masked api key used for the request.
+		Key string
+	}
+
+	// RequestStats describes the outcome of HTTP request sent to Steam.
+	RequestStats struct {
+		// StatusCode is HTTP status code, zero when no response was received.
+		StatusCode int
+
+		// Duration is a time from sending the request to reading the response body.
+		Duration time.Duration
+
+		// Bytes is a number of response body bytes received from Steam.
+		Bytes int64
+
+		// DecodedBytes is a number of response body bytes after decompression.
+		DecodedBytes int64
+
+		// Err is a request error, it is safe to log.
+		Err error
+	}
+
+	// CallInstrumenter is an Instrumenter that also receives events about
+	// Client method calls. A call may be served from the cache without any
+	// request or consist of several requests when api keys are rejected.
+	CallInstrumenter interface {
+		Instrumenter
+
+		// CallStarted is called before the call is processed. Returned
+		// context is used for the call and passed to CallFinished.
+		CallStarted(ctx context.Context, info CallInfo) context.Context
+
+		// CallFinished is called when the call returns.
+		CallFinished(ctx context.Context, info CallInfo, stats CallStats)
+	}
+
+	// CallInfo describes Client method call.
+	CallInfo struct {
+		Interface  string
+		Method     string
+		Version    int
+		HTTPMethod string
+	}
+
+	// CallStats describes the outcome of Client method call.
+	CallStats struct {
+		// StatusCode is HTTP status code of the last response, zero when
+		// no response was received by the call.
+		StatusCode int
+
+		// Attempts is a number of requests sent by the call. It is zero when
+		// the response was served from the cache. Calls sharing one round
+		// trip with identical in-flight calls all report its requests.
+		Attempts int
+
+		// Items is a number of Steam IDs or servers returned by the call,
+		// -1 when the method does not return a known list.
+		Items int
+
+		// Err is a call error, it is safe to log.
+		Err error
+	}
+)
+
+// callState collects stats of requests sent by a call.
+type callState struct {
+	attempts atomic.Int64
+	status   atomic.Int64
+}
+
+type callStateKey struct{}
+
+// requestSent records request sent by the instrumented call of ctx.
+// Zero status means that no response was received.
+func requestSent(ctx context.Context, status int) {
+	if state, ok := ctx.Value(callStateKey{}).(*callState); ok {
+		state.attempts.Add(1)
+		state.status.Store(int64(status))
+	}
+}
+
+// merge records requests of the other state as sent by s.
+func (s *callState) merge(other *callState) {
+	if attempts := other.attempts.Load(); attempts > 0 {
+		s.attempts.Add(attempts)
+		s.status.Store(other.status.Load())
+	}
+}
+
+// instrumenters calls every Instrumenter in order.
+type instrumenters []Instrumenter
+
+func (list instrumenters) RequestStarted(ctx context.Context, info RequestInfo) context.Context {
+	for _, instrumenter := range list {
+		ctx = instrumenter.RequestStarted(ctx, info)
+	}
+
+	return ctx
+}
+
+func (list instrumenters) RequestFinished(ctx context.Context, info RequestInfo, stats RequestStats) {
+	for _, instrumenter := range list {
+		instrumenter.RequestFinished(ctx, info, stats)
+	}
+}
+
+func (list instrumenters) CallStarted(ctx context.Context, info CallInfo) context.Context {
+	for _, instrumenter := range list {
+		if callInstrumenter, ok := instrumenter.(CallInstrumenter); ok {
+			ctx = callInstrumenter.CallStarted(ctx, info)
+		}
+	}
+
+	return ctx
+}
+
+func (list instrumenters) CallFinished(ctx context.Context, info CallInfo, stats CallStats) {
+	for _, instrumenter := range list {
+		if callInstrumenter, ok := instrumenter.(CallInstrumenter); ok {
+			callInstrumenter.CallFinished(ctx, info, stats)
+		}
+	}
+}
+
+// ErrorClass returns a short low cardinality class of the request error
+// suitable for metric labels.
+func ErrorClass(err error) string {
+	var netErr net.Error
+
+	switch {
+	case err == nil:
+		return ErrorClassNone
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.Is(err, ErrWrongStatusCode), errors.Is(err, ErrEmptyResponse):
+		return ErrorClassStatus
+	case errors.Is(err, ErrNoAvailableKeys):
+		return ErrorClassNoKeys
+	case errors.Is(err, ErrResponseTooLarge), errors.Is(err, ErrTruncatedResponse),
+		errors.Is(err, ErrUnsupportedEncoding), errors.Is(err, ErrUnexpectedResponse):
+		return ErrorClassResponse
+	}
+
+	var (
+		urlErr *url.Error
+		opErr  *net.OpError
+	)
+
+	if errors.As(err, &urlErr) || errors.As(err, &opErr) {
+		return ErrorClassNetwork
+	}
+
+	return ErrorClassOther
+}
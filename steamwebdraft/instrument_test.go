@@ -0,0 +1,84 @@
+package steamweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: ErrorClassNone},
+		{err: fmt.Errorf("wrapped: %w", context.Canceled), want: ErrorClassCanceled},
+		{err: context.DeadlineExceeded, want: ErrorClassTimeout},
+		{err: &url.Error{Op: "Get", URL: "http://steam.test", Err: timeoutError{}}, want: ErrorClassTimeout},
+		{err: fmt.Errorf("%w: 500", ErrWrongStatusCode), want: ErrorClassStatus},
+		{err: ErrNoAvailableKeys, want: ErrorClassNoKeys},
+		{err: &url.Error{Op: "Get", URL: "http://steam.test", Err: &net.OpError{Op: "dial", Err: errors.New("refused")}}, want: ErrorClassNetwork},
+		{err: errors.New("unknown"), want: ErrorClassOther},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ErrorClass(tt.err), "ErrorClass(%v)", tt.err)
+	}
+}
+
+type callRecorder struct {
+	mu    sync.Mutex
+	stats []CallStats
+}
+
+func (r *callRecorder) RequestStarted(ctx context.Context, _ RequestInfo) context.Context { return ctx }
+
+func (r *callRecorder) RequestFinished(context.Context, RequestInfo, RequestStats) {}
+
+func (r *callRecorder) CallStarted(ctx context.Context, _ CallInfo) context.Context { return ctx }
+
+func (r *callRecorder) CallFinished(_ context.Context, _ CallInfo, stats CallStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stats = append(r.stats, stats)
+}
+
+func TestCallInstrumenter(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, `{"players":[{"SteamId":"1"}]}`), nil
+	})
+
+	cfg := newConfig("http://steam.test")
+	cfg.Cache.Enabled = true
+
+	recorder := &callRecorder{}
+	client := NewClient(cfg, WithTransport(transport), WithInstrumenter(recorder))
+
+	for range 2 {
+		_, err := client.GetPlayerBans("1")
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, client.Call(context.Background(), "ISteamUser", "GetPlayerSummaries", 2, nil, nil))
+
+	assert.Equal(t, []CallStats{
+		{StatusCode: http.StatusOK, Attempts: 1, Items: 1},
+		{Items: 1},
+		{StatusCode: http.StatusOK, Attempts: 1, Items: -1},
+	}, recorder.stats)
+}
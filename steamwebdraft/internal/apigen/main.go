@@ -0,0 +1,319 @@
+// Command apigen generates typed params and Client methods from a saved
+// response of ISteamWebAPIUtil/GetSupportedAPIList.
+//
+// The snapshot is checked in, so code can be regenerated offline:
+//
+//	go generate ./steamwebdraft
+//
+// To refresh the snapshot download it with a key, so that methods
+// requiring one are listed too:
+//
+//	curl 'https://api.steampowered.com/ISteamWebAPIUtil/GetSupportedAPIList/v1/?key=XXX' > supported_api_list.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// skippedParams are handled by Client itself.
+var skippedParams = map[string]bool{"key": true, "format": true, "input_json": true}
+
+// words are known lowercase words of param names with their Go spelling.
+var words = map[string]string{
+	"addr":             "Addr",
+	"appid":            "AppID",
+	"appids":           "AppIDs",
+	"gameid":           "GameID",
+	"id":               "ID",
+	"ids":              "IDs",
+	"ip":               "IP",
+	"ips":              "IPs",
+	"steamid":          "SteamID",
+	"steamids":         "SteamIDs",
+	"url":              "URL",
+	"vanityurl":        "VanityURL",
+	"appinfo":          "AppInfo",
+	"maxlength":        "MaxLength",
+	"enddate":          "EndDate",
+	"itemcount":        "ItemCount",
+	"l":                "Language",
+	"publishedfileids": "PublishedFileIDs",
+	"collectioncount":  "CollectionCount",
+}
+
+var types = map[string]string{
+	"string":    "string",
+	"bool":      "bool",
+	"int32":     "int32",
+	"int64":     "int64",
+	"uint32":    "uint32",
+	"uint64":    "uint64",
+	"float":     "float64",
+	"{enum}":    "int32",
+	"{message}": "json.RawMessage",
+	"rawbinary": "string",
+}
+
+var errUnknownType = errors.New("unknown param type")
+
+type (
+	apiList struct {
+		APIList struct {
+			Interfaces []apiInterface `json:"interfaces"`
+		} `json:"apilist"`
+	}
+
+	apiInterface struct {
+		Name    string      `json:"name"`
+		Methods []apiMethod `json:"methods"`
+	}
+
+	apiMethod struct {
+		Name        string     `json:"name"`
+		Version     int        `json:"version"`
+		HTTPMethod  string     `json:"httpmethod"`
+		Description string     `json:"description"`
+		Parameters  []apiParam `json:"parameters"`
+	}
+
+	apiParam struct {
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Optional    bool   `json:"optional"`
+		Description string `json:"description"`
+	}
+)
+
+type (
+	method struct {
+		Interface   string
+		Method      string
+		Version     int
+		HTTPMethod  string
+		Description string
+		Name        string
+		Params      []param
+	}
+
+	param struct {
+		Name        string
+		Field       string
+		Type        string
+		Tag         string
+		Description string
+	}
+)
+
+func main() {
+	in := flag.String("in", "supported_api_list.json", "path to GetSupportedAPIList response")
+	out := flag.String("out", "api_gen.go", "path to generated file")
+	pkg := flag.String("package", "steamweb", "package name of generated file")
+	flag.Parse()
+
+	input, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer input.Close()
+
+	code, err := generate(input, *in, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, code, 0o644); err != nil { //nolint:gosec,mnd // Source file permissions.
+		log.Fatal(err)
+	}
+}
+
+// generate reads GetSupportedAPIList response and returns formatted Go code.
+func generate(r io.Reader, source, pkg string) ([]byte, error) {
+	var list apiList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", source, err)
+	}
+
+	methods, err := collect(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	err = codeTemplate.Execute(&buf, struct {
+		Source  string
+		Package string
+		Methods []method
+		JSON    bool
+	}{Source: source, Package: pkg, Methods: methods, JSON: usesJSON(methods)})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func collect(list apiList) ([]method, error) {
+	var methods []method
+
+	for _, iface := range list.APIList.Interfaces {
+		for _, m := range iface.Methods {
+			generated := method{
+				Interface:   iface.Name,
+				Method:      m.Name,
+				Version:     m.Version,
+				HTTPMethod:  strings.ToUpper(m.HTTPMethod),
+				Description: sentence(m.Description),
+				Name:        strings.TrimPrefix(iface.Name, "I") + m.Name + fmt.Sprintf("V%d", m.Version),
+			}
+
+			for _, p := range m.Parameters {
+				if skippedParams[p.Name] {
+					continue
+				}
+
+				generatedParam, err := newParam(p)
+				if err != nil {
+					return nil, fmt.Errorf("%s/%s/v%d: %w", iface.Name, m.Name, m.Version, err)
+				}
+
+				generated.Params = append(generated.Params, generatedParam)
+			}
+
+			methods = append(methods, generated)
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	return methods, nil
+}
+
+func newParam(p apiParam) (param, error) {
+	typ, ok := types[p.Type]
+	if !ok {
+		return param{}, fmt.Errorf("%w: %s %s", errUnknownType, p.Name, p.Type)
+	}
+
+	name, array := strings.CutSuffix(p.Name, "[0]")
+
+	steamTag, jsonTag := name, name
+	if p.Optional {
+		steamTag += ",optional"
+		jsonTag += ",omitempty"
+	}
+
+	if array {
+		typ = "[]" + typ
+		steamTag = name + ",array"
+	}
+
+	return param{
+		Name:        name,
+		Field:       fieldName(name),
+		Type:        typ,
+		Tag:         fmt.Sprintf("`json:%q steam:%q`", jsonTag, steamTag),
+		Description: sentence(p.Description),
+	}, nil
+}
+
+// fieldName converts param name like include_appinfo to IncludeAppInfo.
+func fieldName(name string) string {
+	var b strings.Builder
+
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		if known, ok := words[word]; ok {
+			b.WriteString(known)
+
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	return b.String()
+}
+
+// sentence makes description fit into a doc comment.
+func sentence(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return ""
+	}
+
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	s = string(runes)
+
+	if !strings.HasSuffix(s, ".") {
+		s += "."
+	}
+
+	return s
+}
+
+func usesJSON(methods []method) bool {
+	for _, m := range methods {
+		for _, p := range m.Params {
+			if strings.Contains(p.Type, "json.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+var codeTemplate = template.Must(template.New("api").Parse(`// Code generated by apigen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- if .JSON}}
+	"encoding/json"
+{{- end}}
+)
+{{range $m := .Methods}}
+{{- if $m.Params}}
+// {{$m.Name}}Params are params of {{$m.Interface}}/{{$m.Method}}/v{{$m.Version}}.
+type {{$m.Name}}Params struct {
+{{- range $m.Params}}
+	{{- if .Description}}
+	// {{.Description}}
+	{{- end}}
+	{{.Field}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+{{end}}
+// {{$m.Name}} calls {{$m.Interface}}/{{$m.Method}}/v{{$m.Version}}.
+{{- if $m.Description}}
+// {{$m.Description}}
+{{- end}}
+// Response is decoded into out, see Client.Do.
+{{- if $m.Params}}
+func (c *Client) {{$m.Name}}(ctx context.Context, params *{{$m.Name}}Params, out any) error {
+	return c.Do(ctx, newGeneratedRequest("{{$m.Interface}}", "{{$m.Method}}", {{$m.Version}}, "{{$m.HTTPMethod}}", params), out)
+}
+{{- else}}
+func (c *Client) {{$m.Name}}(ctx context.Context, out any) error {
+	return c.Do(ctx, newGeneratedRequest("{{$m.Interface}}", "{{$m.Method}}", {{$m.Version}}, "{{$m.HTTPMethod}}", nil), out)
+}
+{{- end}}
+{{end}}`))
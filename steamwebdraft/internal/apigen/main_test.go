@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	input, err := os.Open("../../supported_api_list.json")
+	require.NoError(t, err)
+	defer input.Close()
+
+	code, err := generate(input, "supported_api_list.json", "steamweb")
+	require.NoError(t, err)
+
+	current, err := os.ReadFile("../../api_gen.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(current), string(code), "api_gen.go is outdated, run go generate ./steamwebdraft")
+}
+
+func TestGenerate(t *testing.T) {
+	const list = `{"apilist":{"interfaces":[{"name":"ISteamRemoteStorage","methods":[
+		{"name":"GetCollectionDetails","version":1,"httpmethod":"POST","parameters":[
+			{"name":"key","type":"string","optional":false},
+			{"name":"collectioncount","type":"uint32","optional":false,"description":"Number of collections being requested"},
+			{"name":"publishedfileids[0]","type":"uint64","optional":false},
+			{"name":"include_children","type":"bool","optional":true},
+			{"name":"input","type":"{message}","optional":true}
+		]}]}]}}`
+
+	code, err := generate(strings.NewReader(list), "list.json", "steamweb")
+	require.NoError(t, err)
+
+	// Ignore gofmt alignment.
+	normalized := strings.Join(strings.Fields(string(code)), " ")
+
+	for _, want := range []string{
+		`"encoding/json"`,
+		"type SteamRemoteStorageGetCollectionDetailsV1Params struct {",
+		"// Number of collections being requested.",
+		"CollectionCount uint32 `json:\"collectioncount\" steam:\"collectioncount\"`",
+		"PublishedFileIDs []uint64 `json:\"publishedfileids\" steam:\"publishedfileids,array\"`",
+		"IncludeChildren bool `json:\"include_children,omitempty\" steam:\"include_children,optional\"`",
+		"Input json.RawMessage",
+		`newGeneratedRequest("ISteamRemoteStorage", "GetCollectionDetails", 1, "POST", params)`,
+	} {
+		assert.Contains(t, normalized, want)
+	}
+
+	assert.NotContains(t, normalized, "Key string")
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := generate(strings.NewReader(strings.ReplaceAll(list, `"bool"`, `"complex"`)), "list.json", "steamweb")
+		assert.ErrorIs(t, err, errUnknownType)
+	})
+}
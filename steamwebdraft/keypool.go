@@ -0,0 +1,240 @@
+package steamweb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Key selection strategies.
+const (
+	// KeySelectionRoundRobin uses healthy keys one after another.
+	KeySelectionRoundRobin = "round_robin"
+	// KeySelectionLeastUsed uses a healthy key with the fewest calls made today.
+	KeySelectionLeastUsed = "least_used"
+)
+
+const DefaultKeyQuarantine = 5 * time.Minute
+
+var ErrNoAvailableKeys = errors.New("no available api keys")
+
+// KeyStats describes usage and health of a single API key.
+type KeyStats struct {
+	// Key is a masked API key safe for logging.
+	Key string `json:"key"`
+
+	// Calls is a number of requests made with the key since the client was created.
+	Calls int64 `json:"calls"`
+
+	// CallsToday is a number of requests made with the key since UTC midnight.
+	CallsToday int64 `json:"calls_today"`
+
+	// Failures is a number of requests rejected with 403 or 429 status.
+	Failures int64 `json:"failures"`
+
+	// LastStatus is HTTP status code of the last response.
+	LastStatus int `json:"last_status"`
+
+	// QuarantinedUntil is set while the key is not used after a rejection.
+	QuarantinedUntil time.Time `json:"quarantined_until"`
+
+	// Healthy reports whether the key can be used for the next request.
+	Healthy bool `json:"healthy"`
+}
+
+type poolKey struct {
+	value            string
+	calls            int64
+	callsToday       int64
+	day              time.Time
+	failures         int64
+	lastStatus       int
+	quarantinedUntil time.Time
+}
+
+// keyPool picks API keys for requests and tracks their usage.
+type keyPool struct {
+	mu         sync.Mutex
+	keys       []*poolKey
+	selection  string
+	quarantine time.Duration
+	dailyLimit int64
+	next       int
+	now        func() time.Time
+}
+
+func newKeyPool(cfg *Config) *keyPool {
+	pool := &keyPool{
+		selection:  cfg.KeySelection,
+		quarantine: cfg.KeyQuarantine,
+		dailyLimit: int64(cfg.KeyDailyLimit),
+		now:        time.Now,
+	}
+
+	seen := make(map[string]bool)
+
+	for _, key := range append([]string{cfg.Key}, cfg.Keys...) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			pool.keys = append(pool.keys, &poolKey{value: key})
+		}
+	}
+
+	return pool
+}
+
+// size returns a number of keys in the pool.
+func (p *keyPool) size() int {
+	return len(p.keys)
+}
+
+// acquire returns a healthy key and counts a call made with it.
+func (p *keyPool) acquire() (*poolKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+
+	var picked *poolKey
+
+	for i := range p.keys {
+		index := (p.next + i) % len(p.keys)
+		key := p.keys[index]
+		key.resetDay(now)
+
+		if !key.available(now, p.dailyLimit) {
+			continue
+		}
+
+		if p.selection != KeySelectionLeastUsed {
+			picked = key
+			p.next = index + 1
+
+			break
+		}
+
+		if picked == nil || key.callsToday < picked.callsToday {
+			picked = key
+		}
+	}
+
+	if picked == nil {
+		return nil, fmt.Errorf("%w: %d keys are quarantined or exhausted", ErrNoAvailableKeys, len(p.keys))
+	}
+
+	picked.calls++
+	picked.callsToday++
+
+	return picked, nil
+}
+
+// keyRetry tracks keys rejected while one request is retried with other keys.
+type keyRetry struct {
+	// forbidden is a key rejected with 403 status. It is quarantined only
+	// when another key succeeds, a 403 of another key comes from the method.
+	forbidden *poolKey
+}
+
+// release records response status for the key and reports whether the request
+// may be retried with another key. A key rejected with 429 status is
+// quarantined. A key rejected with 403 status is quarantined only when the
+// request succeeds with another key, since publisher-only methods reject
+// every key. The only key of the pool is not quarantined, otherwise a single
+// rejection would fail every request for the quarantine time.
+func (p *keyPool) release(key *poolKey, status int, retry *keyRetry) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key.lastStatus = status
+
+	switch status {
+	case http.StatusTooManyRequests:
+		key.failures++
+
+		if len(p.keys) == 1 {
+			return false
+		}
+
+		key.quarantinedUntil = p.now().Add(p.quarantine)
+
+		return true
+	case http.StatusForbidden:
+		key.failures++
+
+		if retry.forbidden != nil {
+			retry.forbidden = nil
+
+			return false
+		}
+
+		if len(p.keys) == 1 {
+			return false
+		}
+
+		retry.forbidden = key
+
+		return true
+	default:
+		if retry.forbidden != nil && status == http.StatusOK {
+			retry.forbidden.quarantinedUntil = p.now().Add(p.quarantine)
+		}
+
+		retry.forbidden = nil
+
+		return false
+	}
+}
+
+func (p *keyPool) stats() []KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	stats := make([]KeyStats, 0, len(p.keys))
+
+	for _, key := range p.keys {
+		key.resetDay(now)
+
+		stats = append(stats, KeyStats{
+			Key:              maskKey(key.value),
+			Calls:            key.calls,
+			CallsToday:       key.callsToday,
+			Failures:         key.failures,
+			LastStatus:       key.lastStatus,
+			QuarantinedUntil: key.quarantinedUntil,
+			Healthy:          key.available(now, p.dailyLimit),
+		})
+	}
+
+	return stats
+}
+
+func (k *poolKey) available(now time.Time, dailyLimit int64) bool {
+	if now.Before(k.quarantinedUntil) {
+		return false
+	}
+
+	return dailyLimit <= 0 || k.callsToday < dailyLimit
+}
+
+// resetDay resets daily counter when UTC day changes.
+func (k *poolKey) resetDay(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour) //nolint:mnd // Day length.
+	if !k.day.Equal(day) {
+		k.day = day
+		k.callsToday = 0
+	}
+}
+
+// maskKey hides all but the first and the last two characters of the key.
+func maskKey(key string) string {
+	const visible = 2
+
+	if len(key) <= visible*2 {
+		return "****"
+	}
+
+	return key[:visible] + "****" + key[len(key)-visible:]
+}
@@ -0,0 +1,186 @@
+package steamweb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeyPoolServer(t *testing.T, rejected map[string]int) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu   sync.Mutex
+		used []string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+
+		mu.Lock()
+		used = append(used, key)
+		mu.Unlock()
+
+		if status, ok := rejected[key]; ok {
+			w.WriteHeader(status)
+
+			return
+		}
+
+		w.Write([]byte(`{"players":[]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), used...)
+	}
+}
+
+func TestClient_KeyPool(t *testing.T) {
+	t.Run("round robin", func(t *testing.T) {
+		ts, used := newKeyPoolServer(t, nil)
+
+		client := NewClient(&Config{Key: "key-a", Keys: []string{"key-b", "key-c", "key-a"}, URL: ts.URL})
+
+		for range 4 {
+			_, err := client.GetPlayerBans("1")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []string{"key-a", "key-b", "key-c", "key-a"}, used())
+	})
+
+	t.Run("least used", func(t *testing.T) {
+		ts, used := newKeyPoolServer(t, nil)
+
+		client := NewClient(&Config{Keys: []string{"key-a", "key-b"}, KeySelection: KeySelectionLeastUsed, URL: ts.URL})
+		client.keys.keys[0].day = time.Now().UTC().Truncate(24 * time.Hour)
+		client.keys.keys[0].callsToday = 2
+
+		for range 3 {
+			_, err := client.GetPlayerBans("1")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []string{"key-b", "key-b", "key-a"}, used())
+	})
+
+	t.Run("quarantine rejected keys", func(t *testing.T) {
+		ts, used := newKeyPoolServer(t, map[string]int{"key-a": http.StatusTooManyRequests, "key-b": http.StatusForbidden})
+
+		client := NewClient(&Config{Keys: []string{"key-a", "key-b", "key-c"}, URL: ts.URL})
+
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		client.keys.now = func() time.Time { return now }
+
+		_, err := client.GetPlayerBans("1")
+		require.NoError(t, err)
+
+		_, err = client.GetPlayerBans("1")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"key-a", "key-b", "key-c", "key-c"}, used())
+
+		stats := client.KeyStats()
+		if assert.Len(t, stats, 3) {
+			assert.Equal(t, KeyStats{
+				Key:              "ke****-a",
+				Calls:            1,
+				CallsToday:       1,
+				Failures:         1,
+				LastStatus:       http.StatusTooManyRequests,
+				QuarantinedUntil: now.Add(DefaultKeyQuarantine),
+			}, stats[0])
+			assert.Equal(t, now.Add(DefaultKeyQuarantine), stats[1].QuarantinedUntil, "403 key must be quarantined when another key succeeds")
+			assert.Equal(t, KeyStats{Key: "ke****-c", Calls: 2, CallsToday: 2, LastStatus: http.StatusOK, Healthy: true}, stats[2])
+		}
+
+		now = now.Add(DefaultKeyQuarantine)
+
+		assert.True(t, client.KeyStats()[0].Healthy)
+	})
+
+	t.Run("all keys rejected", func(t *testing.T) {
+		ts, _ := newKeyPoolServer(t, map[string]int{"key-a": http.StatusTooManyRequests, "key-b": http.StatusTooManyRequests})
+
+		client := NewClient(&Config{Keys: []string{"key-a", "key-b"}, URL: ts.URL})
+
+		_, err := client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrWrongStatusCode)
+
+		_, err = client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrNoAvailableKeys)
+	})
+
+	t.Run("forbidden method", func(t *testing.T) {
+		rejected := map[string]int{"key-a": http.StatusForbidden, "key-b": http.StatusForbidden, "key-c": http.StatusForbidden}
+		ts, used := newKeyPoolServer(t, rejected)
+
+		client := NewClient(&Config{Keys: []string{"key-a", "key-b", "key-c"}, URL: ts.URL})
+
+		_, err := client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrWrongStatusCode)
+		assert.Equal(t, []string{"key-a", "key-b"}, used())
+
+		for _, stats := range client.KeyStats() {
+			assert.True(t, stats.Healthy, stats.Key)
+		}
+
+		clear(rejected)
+
+		for range 3 {
+			_, err = client.GetPlayerBans("1")
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("single key rejected once", func(t *testing.T) {
+		rejected := map[string]int{"key-a": http.StatusTooManyRequests}
+		ts, used := newKeyPoolServer(t, rejected)
+
+		client := NewClient(&Config{Key: "key-a", URL: ts.URL})
+
+		_, err := client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrWrongStatusCode)
+
+		delete(rejected, "key-a")
+
+		_, err = client.GetPlayerBans("1")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"key-a", "key-a"}, used())
+
+		stats := client.KeyStats()
+		assert.Equal(t, int64(1), stats[0].Failures)
+		assert.True(t, stats[0].Healthy)
+		assert.True(t, stats[0].QuarantinedUntil.IsZero())
+	})
+
+	t.Run("daily limit", func(t *testing.T) {
+		ts, _ := newKeyPoolServer(t, nil)
+
+		client := NewClient(&Config{Key: "key-a", KeyDailyLimit: 1, URL: ts.URL})
+
+		now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+		client.keys.now = func() time.Time { return now }
+
+		_, err := client.GetPlayerBans("1")
+		require.NoError(t, err)
+
+		_, err = client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrNoAvailableKeys)
+
+		now = now.Add(time.Hour)
+
+		_, err = client.GetPlayerBans("1")
+		require.NoError(t, err)
+	})
+}
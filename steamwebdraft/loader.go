@@ -0,0 +1,273 @@
+package steamweb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is a prefix of environment variables read by LoadConfig.
+const EnvPrefix = "STEAMWEB"
+
+// envFileSuffix marks a variable holding a path to a file with the value
+// of a string param, e.g. STEAMWEB_KEY_FILE for Docker and Kubernetes secrets.
+const envFileSuffix = "_FILE"
+
+var ErrConfigFormat = errors.New("unsupported config format")
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// LoadConfig reads Config from JSON or YAML file at path, detected by
+// the file extension, and overlays STEAMWEB_* environment variables on top.
+// Empty path loads Config from environment variables only.
+//
+// Environment variable names are built from the json tags of Config fields,
+// e.g. STEAMWEB_KEY, STEAMWEB_TIMEOUT or STEAMWEB_TRANSPORT_DIALER_TIMEOUT.
+// Every string param can also be read from a file named by the variable
+// with _FILE suffix, e.g. STEAMWEB_KEY_FILE. Lists are comma separated.
+//
+// Durations are accepted both as strings like "10s" and as nanoseconds.
+// Defaults are applied and Config is validated. All problems found while
+// loading are reported at once joined with errors.Join.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+
+	var errs []error
+
+	if path != "" {
+		if err := decodeConfigFile(path, cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, overlayEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix)...)
+
+	// Params loaded so far are validated as well, so that fixing one
+	// problem does not just reveal the next one.
+	cfg.SetDefaults()
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return cfg, nil
+}
+
+func decodeConfigFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var tree any
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &tree)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	default:
+		return fmt.Errorf("%w: %q", ErrConfigFormat, ext)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Both formats are decoded through encoding/json after durations
+	// are normalized, so json tags are the single source of param names.
+	var errs []error
+
+	tree = normalizeDurations(tree, reflect.TypeOf(cfg).Elem(), "", &errs)
+	if len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+
+	normalized, err := json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(normalized, cfg); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// normalizeDurations walks decoded tree along typ and replaces duration
+// strings with nanoseconds understood by encoding/json.
+func normalizeDurations(tree any, typ reflect.Type, path string, errs *[]error) any {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == durationType:
+		if value, ok := tree.(string); ok {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				*errs = append(*errs, fmt.Errorf("%w: %s: %w", ErrConfigInvalidParam, path, err))
+
+				return tree
+			}
+
+			return int64(duration)
+		}
+	case typ.Kind() == reflect.Struct:
+		object, ok := tree.(map[string]any)
+		if !ok {
+			return tree
+		}
+
+		for i := range typ.NumField() {
+			name := fieldName(typ.Field(i))
+			if value, ok := object[name]; ok && name != "" {
+				object[name] = normalizeDurations(value, typ.Field(i).Type, joinPath(path, name), errs)
+			}
+		}
+	case typ.Kind() == reflect.Map:
+		if object, ok := tree.(map[string]any); ok {
+			for name, value := range object {
+				object[name] = normalizeDurations(value, typ.Elem(), joinPath(path, name), errs)
+			}
+		}
+	case typ.Kind() == reflect.Slice:
+		if list, ok := tree.([]any); ok {
+			for i, value := range list {
+				list[i] = normalizeDurations(value, typ.Elem(), joinPath(path, strconv.Itoa(i)), errs)
+			}
+		}
+	}
+
+	return tree
+}
+
+// overlayEnv sets fields of struct value from environment variables.
+func overlayEnv(value reflect.Value, prefix string) []error {
+	var errs []error
+
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+
+		name := fieldName(field)
+		if name == "" || !field.IsExported() {
+			continue
+		}
+
+		env := prefix + "_" + strings.ToUpper(name)
+		target := value.Field(i)
+
+		if target.Kind() == reflect.Struct && target.Type() != timeType {
+			errs = append(errs, overlayEnv(target, env)...)
+
+			continue
+		}
+
+		raw, ok := os.LookupEnv(env)
+
+		if target.Kind() == reflect.String {
+			if file, fileOK := os.LookupEnv(env + envFileSuffix); fileOK && !ok {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%w: %s: %w", ErrConfigInvalidParam, env+envFileSuffix, err))
+
+					continue
+				}
+
+				raw, ok = strings.TrimSpace(string(data)), true
+			}
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := setFromString(target, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s: %w", ErrConfigInvalidParam, env, err))
+		}
+	}
+
+	return errs
+}
+
+func setFromString(target reflect.Value, raw string) error {
+	switch {
+	case target.Type() == durationType:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		target.SetInt(int64(duration))
+	case target.Type() == timeType:
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+
+		target.Set(reflect.ValueOf(parsed))
+	case target.Kind() == reflect.String:
+		target.SetString(raw)
+	case target.Kind() == reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		target.SetBool(parsed)
+	case target.CanInt():
+		parsed, err := strconv.ParseInt(raw, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		target.SetInt(parsed)
+	case target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.String:
+		var list []string
+
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+
+		target.Set(reflect.ValueOf(list).Convert(target.Type()))
+	default:
+		return fmt.Errorf("%w: %s can not be set from environment", ErrConfigFormat, target.Type())
+	}
+
+	return nil
+}
+
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
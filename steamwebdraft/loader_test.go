@@ -0,0 +1,126 @@
+package steamweb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		path := writeFile(t, "steam.yaml", `
+key: yaml-key
+timeout: 15s
+transport:
+  dialer:
+    timeout: 2s
+    keep_alive: 30s
+  idle_conn_timeout: 1m
+default_server_names:
+  - My PZ Server
+`)
+
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, "yaml-key", cfg.Key)
+		assert.Equal(t, DefaultSteamURL, cfg.URL)
+		assert.Equal(t, 15*time.Second, cfg.Timeout)
+		assert.Equal(t, 2*time.Second, cfg.Transport.Dialer.Timeout)
+		assert.Equal(t, 30*time.Second, cfg.Transport.Dialer.KeepAlive)
+		assert.Equal(t, time.Minute, cfg.Transport.IdleConnTimeout)
+		assert.Equal(t, []string{"My PZ Server"}, cfg.DefaultServerNames)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := writeFile(t, "steam.json", `{"key":"json-key","timeout":"3s","transport":{"tls_handshake_timeout":1000000000}}`)
+
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, "json-key", cfg.Key)
+		assert.Equal(t, 3*time.Second, cfg.Timeout)
+		assert.Equal(t, time.Second, cfg.Transport.TLSHandshakeTimeout)
+	})
+
+	t.Run("environment overlay", func(t *testing.T) {
+		path := writeFile(t, "steam.yml", "key: yaml-key\nlimit: 10\n")
+		keyFile := writeFile(t, "key", "secret-key\n")
+
+		t.Setenv("STEAMWEB_KEY_FILE", keyFile)
+		t.Setenv("STEAMWEB_TIMEOUT", "20s")
+		t.Setenv("STEAMWEB_TRANSPORT_ENABLE_HTTP2", "true")
+		t.Setenv("STEAMWEB_TRANSPORT_DIALER_DEADLINE", "2030-01-02T03:04:05Z")
+		t.Setenv("STEAMWEB_DEFAULT_SERVER_NAMES", "My PZ Server, Default")
+
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, "secret-key", cfg.Key)
+		assert.Equal(t, 10, cfg.Limit)
+		assert.Equal(t, 20*time.Second, cfg.Timeout)
+		assert.True(t, cfg.Transport.EnableHTTP2)
+		assert.Equal(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), cfg.Transport.Dialer.Deadline)
+		assert.Equal(t, []string{"My PZ Server", "Default"}, cfg.DefaultServerNames)
+	})
+
+	t.Run("variable wins over file", func(t *testing.T) {
+		t.Setenv("STEAMWEB_KEY", "env-key")
+		t.Setenv("STEAMWEB_KEY_FILE", writeFile(t, "key", "file-key"))
+
+		cfg, err := LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "env-key", cfg.Key)
+	})
+
+	t.Run("all problems at once", func(t *testing.T) {
+		path := writeFile(t, "steam.yaml", "timeout: soon\ntransport:\n  dialer:\n    timeout: never\n")
+
+		t.Setenv("STEAMWEB_LIMIT", "many")
+
+		_, err := LoadConfig(path)
+		require.ErrorIs(t, err, ErrConfigInvalidParam)
+		assert.ErrorContains(t, err, "timeout")
+		assert.ErrorContains(t, err, "transport.dialer.timeout")
+		assert.ErrorContains(t, err, "STEAMWEB_LIMIT")
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		path := writeFile(t, "steam.yaml", "limit: -1\ntransport:\n  proxy: ftp://proxy\n")
+
+		_, err := LoadConfig(path)
+		require.ErrorIs(t, err, ErrConfigUndefinedParam)
+		assert.ErrorContains(t, err, "limit")
+		assert.ErrorContains(t, err, "transport.proxy")
+	})
+
+	t.Run("load and validation", func(t *testing.T) {
+		path := writeFile(t, "steam.yaml", "limit: -1\n")
+
+		t.Setenv("STEAMWEB_TIMEOUT", "soon")
+
+		_, err := LoadConfig(path)
+		require.ErrorIs(t, err, ErrConfigInvalidParam)
+		require.ErrorIs(t, err, ErrConfigUndefinedParam)
+		assert.ErrorContains(t, err, "STEAMWEB_TIMEOUT")
+		assert.ErrorContains(t, err, "limit")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := LoadConfig(writeFile(t, "steam.toml", ""))
+		assert.ErrorIs(t, err, ErrConfigFormat)
+	})
+}
@@ -0,0 +1,65 @@
+package steamweb
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logRequest logs request sent to Steam with a body dump when it is enabled.
+// The uri must be redacted.
+func (c *Client) logRequest(
+	ctx context.Context, r *Request, info RequestInfo, uri string, stats RequestStats, retry bool, body []byte,
+) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("interface", info.Interface),
+		slog.String("method", info.Method),
+		slog.Int("version", info.Version),
+		slog.String("http_method", info.HTTPMethod),
+		slog.String("url", uri),
+		slog.Int("attempt", info.Attempt),
+		slog.String("key", info.Key),
+		slog.Int("status", stats.StatusCode),
+		slog.Duration("duration", stats.Duration),
+		slog.Int64("bytes", stats.Bytes),
+	}
+
+	switch {
+	case retry:
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "steamweb: api key rejected", attrs...)
+	case stats.Err != nil:
+		attrs = append(attrs, slog.String("error", stats.Err.Error()))
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "steamweb: request failed", attrs...)
+	default:
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "steamweb: request", attrs...)
+	}
+
+	if !c.config.Log.DumpBodies || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	// Params never contain the key, it is added when request is built.
+	var params []byte
+	if values, err := r.values(); err == nil {
+		params = []byte(values.Encode())
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "steamweb: request dump",
+		slog.String("url", uri),
+		slog.Int("attempt", info.Attempt),
+		slog.String("params", c.dump(params)),
+		slog.String("body", c.dump(body)),
+	)
+}
+
+// dump returns body cut to Config.Log.DumpLimit bytes.
+func (c *Client) dump(body []byte) string {
+	if limit := c.config.Log.DumpLimit; limit > 0 && len(body) > limit {
+		return string(body[:limit]) + "...(truncated)"
+	}
+
+	return string(body)
+}
@@ -0,0 +1,126 @@
+package steamweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLogs(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestClient_Logger(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasPrefix(req.URL.Query().Get(KeyParam), "rejected-key") {
+			res := jsonResponse(req, `{"error":"rate limited"}`)
+			res.StatusCode = http.StatusTooManyRequests
+
+			return res, nil
+		}
+
+		return jsonResponse(req, `{"players":[{"SteamId":"1234567890"}]}`), nil
+	})
+
+	t.Run("requests", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		cfg := &Config{Keys: []string{"rejected-key", "accepted-key"}}
+		client := NewClient(cfg, WithTransport(transport),
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+		_, err := client.GetPlayerBans("1")
+		require.NoError(t, err)
+
+		assert.NotContains(t, buf.String(), "rejected-key")
+		assert.NotContains(t, buf.String(), "accepted-key")
+
+		records := decodeLogs(t, &buf)
+		require.Len(t, records, 2)
+
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, "steamweb: api key rejected", records[0]["msg"])
+		assert.InDelta(t, http.StatusTooManyRequests, records[0]["status"], 0)
+		assert.Equal(t, "re****ey", records[0]["key"])
+
+		assert.Equal(t, "DEBUG", records[1]["level"])
+		assert.Equal(t, "steamweb: request", records[1]["msg"])
+		assert.Equal(t, DefaultSteamURL+"/ISteamUser/GetPlayerBans/v1?key=REDACTED&steamids=1", records[1]["url"])
+		assert.InDelta(t, http.StatusOK, records[1]["status"], 0)
+		assert.InDelta(t, len(`{"players":[{"SteamId":"1234567890"}]}`), records[1]["bytes"], 0)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		cfg := &Config{Keys: []string{"rejected-key", "rejected-key-2"}, Log: LogConfig{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}}
+		client := NewClient(cfg, WithTransport(transport))
+
+		_, err := client.GetPlayerBans("1")
+		require.Error(t, err)
+
+		_, err = client.GetPlayerBans("1")
+		require.ErrorIs(t, err, ErrNoAvailableKeys)
+
+		records := decodeLogs(t, &buf)
+		require.Len(t, records, 3)
+		assert.Equal(t, "steamweb: api key rejected", records[0]["msg"])
+		assert.Equal(t, "steamweb: api key rejected", records[1]["msg"])
+		assert.Equal(t, "steamweb: request failed", records[2]["msg"])
+		assert.Contains(t, records[2]["error"], ErrNoAvailableKeys.Error())
+	})
+
+	t.Run("dump", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		cfg := &Config{Keys: []string{"rejected-key", "accepted-key"}, KeyInHeader: true}
+		cfg.Log.DumpBodies = true
+		cfg.Log.DumpLimit = 20
+		cfg.Log.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(KeyHeader) == "rejected-key" {
+				res := jsonResponse(req, `{"error":"rate limited"}`)
+				res.StatusCode = http.StatusTooManyRequests
+
+				return res, nil
+			}
+
+			return jsonResponse(req, `{"players":[{"SteamId":"1234567890"}]}`), nil
+		})
+
+		client := NewClient(cfg, WithTransport(transport))
+		req := &Request{Interface: "ISteamUser", Method: "GetPlayerBans", Version: 1, HTTPMethod: http.MethodPost}
+		req.Params = map[string][]string{"steamids": {"1"}}
+
+		require.NoError(t, client.Do(context.Background(), req, nil))
+
+		records := decodeLogs(t, &buf)
+		require.Len(t, records, 4)
+
+		assert.Equal(t, "steamweb: request dump", records[1]["msg"])
+		assert.Equal(t, "steamids=1", records[1]["params"])
+		assert.Equal(t, `{"error":"rate limit...(truncated)`, records[1]["body"])
+
+		assert.Equal(t, "steamweb: request dump", records[3]["msg"])
+		assert.Equal(t, `{"players":[{"SteamI...(truncated)`, records[3]["body"])
+		assert.NotContains(t, buf.String(), "-key")
+	})
+}
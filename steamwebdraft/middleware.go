@@ -0,0 +1,22 @@
+package steamweb
+
+import "net/http"
+
+type (
+	// RequestFunc sends a single HTTP request to Steam Web API.
+	RequestFunc func(req *http.Request) (*http.Response, error)
+
+	// Middleware wraps RequestFunc to inspect or modify requests and responses.
+	// Middleware may short-circuit the chain by not calling next.
+	Middleware func(next RequestFunc) RequestFunc
+)
+
+// chain wraps fn with middlewares. The first middleware is the outermost one,
+// so it sees the request first and the response last.
+func chain(fn RequestFunc, middlewares ...Middleware) RequestFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		fn = middlewares[i](fn)
+	}
+
+	return fn
+}
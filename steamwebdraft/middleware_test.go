@@ -0,0 +1,125 @@
+package steamweb
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func jsonResponse(req *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+
+	record := func(name string) Middleware {
+		return func(next RequestFunc) RequestFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" request")
+
+				res, err := next(req)
+
+				calls = append(calls, name+" response")
+
+				return res, err
+			}
+		}
+	}
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "transport")
+
+		return jsonResponse(req, `{"players":[]}`), nil
+	})
+
+	client := NewClient(newConfig("http://steam.test"),
+		WithTransport(transport),
+		WithMiddleware(record("first"), record("second")),
+		WithMiddleware(record("third")),
+	)
+
+	_, err := client.GetPlayerBans("7656119")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"first request",
+		"second request",
+		"third request",
+		"transport",
+		"third response",
+		"second response",
+		"first response",
+	}, calls)
+}
+
+func TestWithMiddleware_ShortCircuit(t *testing.T) {
+	transport := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("transport must not be called")
+
+		return nil, nil //nolint:nilnil // Unreachable.
+	})
+
+	stub := func(RequestFunc) RequestFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, `{"players":[{"SteamId":"1"}]}`), nil
+		}
+	}
+
+	client := NewClient(newConfig("http://steam.test"), WithTransport(transport), WithMiddleware(stub))
+
+	got, err := client.GetPlayerBans("1")
+	require.NoError(t, err)
+	assert.Equal(t, []PlayerBans{{SteamID: "1"}}, got)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"players":[{"SteamId":%q}]}`, r.Header.Get("X-Test"))
+	}))
+	defer ts.Close()
+
+	custom := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Test", "custom")
+
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	client := NewClient(newConfig(ts.URL), WithHTTPClient(custom))
+
+	got, err := client.GetPlayerBans("1")
+	require.NoError(t, err)
+	assert.Equal(t, []PlayerBans{{SteamID: "custom"}}, got)
+
+	t.Run("transport does not modify custom client", func(t *testing.T) {
+		transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, `{"players":[]}`), nil
+		})
+
+		NewClient(newConfig(ts.URL), WithHTTPClient(custom), WithTransport(transport))
+
+		got, err := NewClient(newConfig(ts.URL), WithHTTPClient(custom)).GetPlayerBans("1")
+		require.NoError(t, err)
+		assert.Equal(t, []PlayerBans{{SteamID: "custom"}}, got)
+	})
+}
@@ -0,0 +1,110 @@
+package steamweb
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultServerNamesByApp is a built-in catalogue of default server names
+// keyed by AppID. It is used with NoDefaultServers filter, entries are
+// overridden by Config.AppDefaultServerNames. Patterns have the syntax of
+// Config.DefaultServerNames. Only names shipped as defaults by the game
+// itself are listed, other games are configured by the user.
+var DefaultServerNamesByApp = map[int][]string{
+	// Project Zomboid: PublicName=My PZ Server in the servertest.ini
+	// written by the dedicated server on the first start.
+	108600: {"My PZ Server"},
+}
+
+// serverNames matches server names against default name patterns.
+type serverNames struct {
+	all  []*regexp.Regexp
+	apps map[int][]*regexp.Regexp
+}
+
+// newServerNames compiles patterns of Config and the built-in catalogue.
+// Invalid patterns are skipped, they are reported by Config.Validate.
+func newServerNames(cfg *Config) *serverNames {
+	names := &serverNames{
+		all:  compileNamePatterns(cfg.DefaultServerNames),
+		apps: make(map[int][]*regexp.Regexp),
+	}
+
+	for appID, patterns := range DefaultServerNamesByApp {
+		names.apps[appID] = compileNamePatterns(patterns)
+	}
+
+	for appID, patterns := range cfg.AppDefaultServerNames {
+		names.apps[appID] = compileNamePatterns(patterns)
+	}
+
+	return names
+}
+
+// match reports whether the server has a default name of its app.
+func (n *serverNames) match(server *Server) bool {
+	name := strings.TrimSpace(server.Name)
+
+	for _, re := range n.all {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+
+	for _, re := range n.apps[server.AppID] {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compileNamePatterns(patterns []string) []*regexp.Regexp {
+	list := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		if re, err := compileNamePattern(pattern); err == nil {
+			list = append(list, re)
+		}
+	}
+
+	return list
+}
+
+// compileNamePattern compiles case-insensitive pattern of a server name:
+// /regexp/, glob with * and ? wildcards or an exact name.
+func compileNamePattern(pattern string) (*regexp.Regexp, error) {
+	switch {
+	case len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/"):
+		re, err := regexp.Compile("(?i)" + pattern[1:len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrConfigInvalidParam, "default_server_names", err)
+		}
+
+		return re, nil
+	case strings.ContainsAny(pattern, "*?"):
+		return globRegexp(pattern), nil
+	default:
+		return regexp.MustCompile("(?i)^" + regexp.QuoteMeta(pattern) + "$"), nil
+	}
+}
+
+// validateNamePatterns reports invalid patterns of default server names.
+func validateNamePatterns(cfg *Config) []error {
+	var errs []error
+
+	patterns := cfg.DefaultServerNames
+	for _, list := range cfg.AppDefaultServerNames {
+		patterns = append(patterns[:len(patterns):len(patterns)], list...)
+	}
+
+	for _, pattern := range patterns {
+		if _, err := compileNamePattern(pattern); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,79 @@
+package steamweb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerNames(t *testing.T) {
+	names := newServerNames(&Config{
+		DefaultServerNames: []string{"Default Server", "/^server #\\d+$/", "[invalid", "/[invalid/"},
+		AppDefaultServerNames: map[int][]string{
+			252490: {"Rust Server ?"},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		server Server
+		want   bool
+	}{
+		{name: "exact", server: Server{Name: "Default Server"}, want: true},
+		{name: "exact ignores case and spaces", server: Server{Name: " default SERVER "}, want: true},
+		{name: "exact is not prefix", server: Server{Name: "Default Server 2"}, want: false},
+		{name: "regexp", server: Server{Name: "Server #12"}, want: true},
+		{name: "regexp mismatch", server: Server{Name: "Server #x"}, want: false},
+		{name: "invalid glob is literal", server: Server{Name: "[invalid"}, want: true},
+		{name: "catalogue", server: Server{AppID: 108600, Name: " my pz server"}, want: true},
+		{name: "catalogue is exact", server: Server{AppID: 108600, Name: "My PZ Server 2"}, want: false},
+		{name: "catalogue of another app", server: Server{AppID: 440, Name: "My PZ Server"}, want: false},
+		{name: "app patterns", server: Server{AppID: 252490, Name: "rust server 1"}, want: true},
+		{name: "custom name", server: Server{AppID: 108600, Name: "Best Server"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names.match(&tt.server))
+		})
+	}
+
+	t.Run("disabled catalogue", func(t *testing.T) {
+		names := newServerNames(&Config{AppDefaultServerNames: map[int][]string{108600: {}}})
+		assert.False(t, names.match(&Server{AppID: 108600, Name: "My PZ Server"}))
+	})
+
+	t.Run("overridden catalogue", func(t *testing.T) {
+		names := newServerNames(&Config{AppDefaultServerNames: map[int][]string{108600: {"My PZ Server *"}}})
+		assert.False(t, names.match(&Server{AppID: 108600, Name: "My PZ Server"}))
+		assert.True(t, names.match(&Server{AppID: 108600, Name: "My PZ Server 3"}))
+	})
+}
+
+func TestConfig_Validate_DefaultServerNames(t *testing.T) {
+	cfg := &Config{Key: "key", URL: DefaultSteamURL, AppDefaultServerNames: map[int][]string{1: {"/(/"}}}
+	require.ErrorIs(t, cfg.Validate(), ErrConfigInvalidParam)
+
+	cfg.AppDefaultServerNames[1] = []string{"/ok/", "glob*"}
+	require.NoError(t, cfg.Validate())
+}
+
+func TestClient_GetServerList_DefaultServerNames(t *testing.T) {
+	body := `{"response":{"servers":[` +
+		`{"addr":"127.0.0.1:1","appid":108600,"name":"My PZ Server"},` +
+		`{"addr":"127.0.0.2:1","appid":108600,"name":"my pz server"},` +
+		`{"addr":"127.0.0.3:1","appid":108600,"name":"Survivors EU"}]}}`
+
+	cfg := newConfig("http://steam.test")
+	cfg.DefaultServerNames = nil
+
+	client := NewClient(cfg, WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, body), nil
+	})))
+
+	servers, err := client.GetServerList(&GetServerListFilter{AppID: 108600, NoDefaultServers: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.3:1"}, serverAddrs(servers))
+}
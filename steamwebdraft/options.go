@@ -0,0 +1,62 @@
+package steamweb
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Option configures optional Client behaviour that can not be expressed
+// in the serializable Config.
+type Option func(*Client)
+
+// WithHTTPClient replaces http.Client built from Config. Config.Timeout and
+// Config.Transport are ignored in this case, the client is used as is.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.http = client
+		c.transportErr = nil
+	}
+}
+
+// WithTransport replaces the RoundTripper built from Config.Transport.
+// It can be used to plug in proxies, recorders or instrumented transports.
+// The http.Client passed with WithHTTPClient is copied, not modified.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		client := *c.http
+		client.Transport = rt
+		c.http = &client
+		c.transportErr = nil
+	}
+}
+
+// WithMiddleware appends middlewares to the chain wrapping every request.
+// Middlewares are called in the order they were added.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithCache enables response caching with the given Cache instead of
+// the built-in LRUCache. Config.Cache TTL settings still apply.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = newResponseCache(cache, &c.config.Cache, c.config.URL)
+	}
+}
+
+// WithInstrumenter adds Instrumenter receiving events about every request
+// sent to Steam. Instrumenters are called in the order they were added.
+func WithInstrumenter(instrumenter Instrumenter) Option {
+	return func(c *Client) {
+		c.instrumenters = append(c.instrumenters, instrumenter)
+	}
+}
+
+// WithLogger sets logger of requests replacing Config.Log.Logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
@@ -0,0 +1,153 @@
+package steamweb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type (
+	// Page selects a part of sorted server list.
+	Page struct {
+		// Offset is a number of skipped servers. It is ignored with Cursor.
+		Offset int `json:"offset,omitempty"`
+
+		// Size is a maximum number of servers on the page, zero means all.
+		Size int `json:"size,omitempty"`
+
+		// Cursor is ServerPage.NextCursor of the previous page. The page
+		// starts after the last server of the previous page even when
+		// servers appeared or disappeared in between.
+		Cursor string `json:"cursor,omitempty"`
+	}
+
+	// ServerPage is a part of sorted server list.
+	ServerPage struct {
+		Servers []Server `json:"servers"`
+
+		// Offset is a position of the first server in the whole list.
+		Offset int `json:"offset"`
+
+		// Total is a number of servers in the whole list.
+		Total int `json:"total"`
+
+		// NextCursor selects the next page, it is empty on the last page.
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+)
+
+// cursor is a position after the last server of a page. It keeps sort key
+// values of the server, so the position does not depend on fields that are
+// not stored or change between pages, like ping.
+type cursor struct {
+	Sort    []SortOrder `json:"sort"`
+	Values  []sortValue `json:"values"`
+	Addr    string      `json:"addr"`
+	SteamID string      `json:"steamid,omitempty"`
+}
+
+// compare compares the server with the position of the cursor like compareServers.
+func (c *cursor) compare(server *Server, orders []SortOrder) int {
+	for i, order := range orders {
+		if result := order.compareValues(order.value(server), c.Values[i]); result != 0 {
+			return result
+		}
+	}
+
+	if result := strings.Compare(server.Addr, c.Addr); result != 0 {
+		return result
+	}
+
+	return strings.Compare(server.SteamID, c.SteamID)
+}
+
+// GetServerListPage is like GetServerListContext but returns a page of
+// servers sorted by GetServerListFilter.Sort.
+func (c *Client) GetServerListPage(ctx context.Context, filter *GetServerListFilter, page Page) (ServerPage, error) {
+	servers, err := c.GetServerListContext(ctx, filter)
+	if err != nil {
+		return ServerPage{}, err
+	}
+
+	return Paginate(servers, page, filter.Sort...)
+}
+
+// Paginate returns a page of servers sorted by the orders with SortServers.
+func Paginate(servers []Server, page Page, orders ...SortOrder) (ServerPage, error) {
+	if page.Offset < 0 || page.Size < 0 {
+		return ServerPage{}, fmt.Errorf("%w: page: offset and size must not be negative", ErrInvalidParam)
+	}
+
+	orders = sortOrders(orders)
+
+	if !slices.IsSortedFunc(servers, func(a, b Server) int { return compareServers(&a, &b, orders) }) {
+		servers = slices.Clone(servers)
+		SortServers(servers, orders...)
+	}
+
+	start := min(page.Offset, len(servers))
+
+	if page.Cursor != "" {
+		last, err := decodeCursor(page.Cursor, orders)
+		if err != nil {
+			return ServerPage{}, err
+		}
+
+		start = sort.Search(len(servers), func(i int) bool {
+			return last.compare(&servers[i], orders) > 0
+		})
+	}
+
+	end := len(servers)
+	if page.Size > 0 {
+		end = min(start+page.Size, end)
+	}
+
+	result := ServerPage{
+		Servers: servers[start:end],
+		Offset:  start,
+		Total:   len(servers),
+	}
+
+	if end < len(servers) && end > start {
+		result.NextCursor = encodeCursor(&servers[end-1], orders)
+	}
+
+	return result, nil
+}
+
+func encodeCursor(last *Server, orders []SortOrder) string {
+	values := make([]sortValue, len(orders))
+	for i, order := range orders {
+		values[i] = order.value(last)
+	}
+
+	data, _ := json.Marshal(cursor{Sort: orders, Values: values, Addr: last.Addr, SteamID: last.SteamID})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string, orders []SortOrder) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: page: cursor: %w", ErrInvalidParam, err)
+	}
+
+	var decoded cursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("%w: page: cursor: %w", ErrInvalidParam, err)
+	}
+
+	equal := slices.EqualFunc(decoded.Sort, orders, func(a, b SortOrder) bool {
+		return a.Key == b.Key && a.Desc == b.Desc
+	})
+	if !equal || len(decoded.Values) != len(orders) {
+		return nil, fmt.Errorf("%w: page: cursor was created with another sort order", ErrInvalidParam)
+	}
+
+	return &decoded, nil
+}
@@ -0,0 +1,99 @@
+package steamweb
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run ./internal/apigen -in supported_api_list.json -out api_gen.go
+
+// ParamsTag is a struct tag describing how a field is encoded into request params:
+// `steam:"name"` for required params, `steam:"name,optional"` for params omitted
+// when zero and `steam:"name,array"` for slices encoded as name[0], name[1], ...
+const ParamsTag = "steam"
+
+// EncodeParams encodes struct fields tagged with ParamsTag into url.Values.
+// It is used by generated methods and can be used with Client.Call.
+// Nil params are encoded as empty url.Values.
+func EncodeParams(params any) url.Values {
+	values := url.Values{}
+
+	value := reflect.ValueOf(params)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return values
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return values
+	}
+
+	for i := range value.NumField() {
+		tag, ok := value.Type().Field(i).Tag.Lookup(ParamsTag)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		field := value.Field(i)
+
+		switch {
+		case options == "array":
+			for j := range field.Len() {
+				values.Set(name+"["+strconv.Itoa(j)+"]", formatParam(field.Index(j)))
+			}
+		case options == "optional" && field.IsZero():
+		default:
+			values.Set(name, formatParam(field))
+		}
+	}
+
+	return values
+}
+
+func formatParam(value reflect.Value) string {
+	switch value.Kind() { //nolint:exhaustive // Other kinds are formatted with fmt.
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits())
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+// newGeneratedRequest builds Request for generated methods. Params of service
+// interfaces are sent as input_json, params of other interfaces are encoded
+// with EncodeParams.
+func newGeneratedRequest(iface, method string, version int, httpMethod string, params any) *Request {
+	req := &Request{
+		Interface:  iface,
+		Method:     method,
+		Version:    version,
+		HTTPMethod: httpMethod,
+	}
+
+	if params == nil || (reflect.ValueOf(params).Kind() == reflect.Pointer && reflect.ValueOf(params).IsNil()) {
+		return req
+	}
+
+	if strings.HasSuffix(iface, "Service") {
+		req.InputJSON = params
+	} else {
+		req.Params = EncodeParams(params)
+	}
+
+	return req
+}
@@ -0,0 +1,83 @@
+package steamweb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeParams(t *testing.T) {
+	type params struct {
+		AppID    uint32   `steam:"appid"`
+		Count    int      `steam:"count,optional"`
+		Language string   `steam:"l,optional"`
+		Enabled  bool     `steam:"enabled"`
+		Ratio    float64  `steam:"ratio,optional"`
+		IDs      []uint64 `steam:"publishedfileids,array"`
+		Ignored  string
+	}
+
+	assert.Equal(t, url.Values{
+		"appid":               {"108600"},
+		"enabled":             {"false"},
+		"ratio":               {"0.5"},
+		"publishedfileids[0]": {"1"},
+		"publishedfileids[1]": {"2"},
+	}, EncodeParams(&params{AppID: 108600, Ratio: 0.5, IDs: []uint64{1, 2}, Ignored: "x"}))
+
+	assert.Equal(t, url.Values{}, EncodeParams((*params)(nil)))
+	assert.Equal(t, url.Values{}, EncodeParams(nil))
+}
+
+func TestClient_GeneratedMethods(t *testing.T) {
+	var got *http.Request
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		got = r
+
+		w.Write([]byte(`{"response":{"player_level":42}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(newConfig(ts.URL))
+
+	t.Run("service interface", func(t *testing.T) {
+		var out struct {
+			Response struct {
+				PlayerLevel int `json:"player_level"`
+			} `json:"response"`
+		}
+
+		err := client.PlayerServiceGetSteamLevelV1(context.Background(), &PlayerServiceGetSteamLevelV1Params{SteamID: 76561197960435530}, &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, "/IPlayerService/GetSteamLevel/v1", got.URL.Path)
+		assert.JSONEq(t, `{"steamid":76561197960435530}`, got.Form.Get(InputJSONParam))
+		assert.Equal(t, 42, out.Response.PlayerLevel)
+	})
+
+	t.Run("array params", func(t *testing.T) {
+		err := client.SteamRemoteStorageGetPublishedFileDetailsV1(context.Background(), &SteamRemoteStorageGetPublishedFileDetailsV1Params{
+			ItemCount:        2,
+			PublishedFileIDs: []uint64{10, 20},
+		}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, got.Method)
+		assert.Equal(t, "2", got.PostForm.Get("itemcount"))
+		assert.Equal(t, "10", got.PostForm.Get("publishedfileids[0]"))
+		assert.Equal(t, "20", got.PostForm.Get("publishedfileids[1]"))
+	})
+
+	t.Run("no params", func(t *testing.T) {
+		require.NoError(t, client.SteamWebAPIUtilGetServerInfoV1(context.Background(), nil))
+		assert.Equal(t, "/ISteamWebAPIUtil/GetServerInfo/v1", got.URL.Path)
+	})
+}
@@ -0,0 +1,224 @@
+package steamweb
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ServerPredicate reports whether the server passes a client-side filter.
+// Predicates are set in GetServerListFilter.Match and applied to servers
+// returned by Steam.
+type ServerPredicate func(server *Server) bool
+
+// AllOf matches servers matching every predicate.
+func AllOf(predicates ...ServerPredicate) ServerPredicate {
+	return func(server *Server) bool {
+		for _, predicate := range predicates {
+			if !predicate(server) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// AnyOf matches servers matching at least one predicate.
+func AnyOf(predicates ...ServerPredicate) ServerPredicate {
+	return func(server *Server) bool {
+		for _, predicate := range predicates {
+			if predicate(server) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not matches servers not matching the predicate.
+func Not(predicate ServerPredicate) ServerPredicate {
+	return func(server *Server) bool {
+		return !predicate(server)
+	}
+}
+
+// PlayersAtLeast matches servers with at least n players.
+func PlayersAtLeast(n int) ServerPredicate {
+	return func(server *Server) bool {
+		return server.Players >= n
+	}
+}
+
+// PlayersAtMost matches servers with at most n players.
+func PlayersAtMost(n int) ServerPredicate {
+	return func(server *Server) bool {
+		return server.Players <= n
+	}
+}
+
+// FreeSlotsAtLeast matches servers with at least n free player slots.
+func FreeSlotsAtLeast(n int) ServerPredicate {
+	return func(server *Server) bool {
+		return server.MaxPlayers-server.Players >= n
+	}
+}
+
+// BotsRatioAtMost matches servers where bots make at most ratio of players,
+// e.g. 0.5 for a half. Empty servers always match.
+func BotsRatioAtMost(ratio float64) ServerPredicate {
+	return func(server *Server) bool {
+		if server.Players <= 0 {
+			return true
+		}
+
+		return float64(server.Bots)/float64(server.Players) <= ratio
+	}
+}
+
+// OSIn matches servers running on one of the platforms reported by Steam:
+// "w" for Windows, "l" for Linux and "m" for macOS. Legacy "o" code of
+// macOS servers matches OSMacOS.
+func OSIn(platforms ...OS) ServerPredicate {
+	return func(server *Server) bool {
+		return slices.ContainsFunc(platforms, func(platform OS) bool {
+			return platform.normalize() == server.OS.normalize()
+		})
+	}
+}
+
+// RegionIn matches servers located in one of the regions, unlike
+// GetServerListFilter.Regions RegionWorld matches only servers reporting it.
+func RegionIn(regions ...Region) ServerPredicate {
+	return func(server *Server) bool {
+		return slices.ContainsFunc(regions, func(region Region) bool {
+			return region.normalize() == server.Region.normalize()
+		})
+	}
+}
+
+// SecureIs matches servers with the given VAC status.
+func SecureIs(secure bool) ServerPredicate {
+	return func(server *Server) bool {
+		return server.Secure == secure
+	}
+}
+
+// NameRegexp matches servers with the name matching re.
+func NameRegexp(re *regexp.Regexp) ServerPredicate {
+	return func(server *Server) bool {
+		return re.MatchString(server.Name)
+	}
+}
+
+// NameGlob matches servers with the name matching the pattern, where
+// * matches any text and ? matches any character. Case is ignored.
+func NameGlob(pattern string) ServerPredicate {
+	return NameRegexp(globRegexp(pattern))
+}
+
+// MapRegexp matches servers with the map matching re.
+func MapRegexp(re *regexp.Regexp) ServerPredicate {
+	return func(server *Server) bool {
+		return re.MatchString(server.Map)
+	}
+}
+
+// MapGlob is like NameGlob but matches the map.
+func MapGlob(pattern string) ServerPredicate {
+	return MapRegexp(globRegexp(pattern))
+}
+
+// VersionBetween matches servers with the version in the inclusive range.
+// Versions are compared with Version.Compare, so "1.0-beta" is below "1.0".
+// Empty bound means no bound.
+func VersionBetween(minVersion, maxVersion string) ServerPredicate {
+	lower, upper := ParseVersion(minVersion), ParseVersion(maxVersion)
+
+	return func(server *Server) bool {
+		version := server.ParsedVersion()
+
+		if minVersion != "" && version.Compare(lower) < 0 {
+			return false
+		}
+
+		return maxVersion == "" || version.Compare(upper) <= 0
+	}
+}
+
+// TagsInclude matches servers with all of the tags in GameType.
+func TagsInclude(tags ...string) ServerPredicate {
+	return func(server *Server) bool {
+		return server.Tags().HasAll(tags...)
+	}
+}
+
+// TagsExclude matches servers with none of the tags in GameType.
+func TagsExclude(tags ...string) ServerPredicate {
+	return func(server *Server) bool {
+		return !server.Tags().HasAny(tags...)
+	}
+}
+
+// CompareVersions compares dot separated versions like 41.78.16 and returns
+// -1, 0 or +1. Numeric parts are compared as numbers, other parts as strings,
+// missing parts are zero. It does not know pre-releases, server versions
+// are ordered with Version.Compare.
+func CompareVersions(a, b string) int {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := range max(len(partsA), len(partsB)) {
+		partA, partB := "0", "0"
+
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+
+		numA, errA := strconv.ParseUint(partA, 10, 64)
+		numB, errB := strconv.ParseUint(partB, 10, 64)
+
+		var result int
+
+		if errA == nil && errB == nil {
+			result = cmp.Compare(numA, numB)
+		} else {
+			result = strings.Compare(partA, partB)
+		}
+
+		if result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// globRegexp converts glob pattern into case-insensitive regexp matching
+// the whole string.
+func globRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+
+	b.WriteString("(?is)^")
+
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String())
+}
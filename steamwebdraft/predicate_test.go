@@ -0,0 +1,131 @@
+package steamweb
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerPredicates(t *testing.T) {
+	server := &Server{
+		Name:       "My PZ Server [EU] PvP",
+		Map:        "Muldraugh, KY",
+		Version:    "41.78.16",
+		Region:     3,
+		Players:    10,
+		MaxPlayers: 32,
+		Bots:       2,
+		Secure:     true,
+		OS:         "l",
+		GameType:   "hidden; hosted,pvp",
+	}
+
+	tests := []struct {
+		name      string
+		predicate ServerPredicate
+		want      bool
+	}{
+		{name: "players at least", predicate: PlayersAtLeast(10), want: true},
+		{name: "players at least more", predicate: PlayersAtLeast(11), want: false},
+		{name: "players at most", predicate: PlayersAtMost(10), want: true},
+		{name: "players at most less", predicate: PlayersAtMost(9), want: false},
+		{name: "free slots", predicate: FreeSlotsAtLeast(22), want: true},
+		{name: "free slots more", predicate: FreeSlotsAtLeast(23), want: false},
+		{name: "bots ratio", predicate: BotsRatioAtMost(0.2), want: true},
+		{name: "bots ratio less", predicate: BotsRatioAtMost(0.1), want: false},
+		{name: "os", predicate: OSIn("w", "l"), want: true},
+		{name: "other os", predicate: OSIn("w"), want: false},
+		{name: "region", predicate: RegionIn(1, 3), want: true},
+		{name: "other region", predicate: RegionIn(0), want: false},
+		{name: "secure", predicate: SecureIs(true), want: true},
+		{name: "insecure", predicate: SecureIs(false), want: false},
+		{name: "name regexp", predicate: NameRegexp(regexp.MustCompile(`\[EU\]`)), want: true},
+		{name: "name regexp mismatch", predicate: NameRegexp(regexp.MustCompile(`^\[EU\]`)), want: false},
+		{name: "name glob", predicate: NameGlob("my pz server*"), want: true},
+		{name: "name glob question mark", predicate: NameGlob("My PZ Server [??] PvP"), want: true},
+		{name: "name glob whole string", predicate: NameGlob("PZ*"), want: false},
+		{name: "map regexp", predicate: MapRegexp(regexp.MustCompile(`^Muldraugh`)), want: true},
+		{name: "map glob", predicate: MapGlob("*, KY"), want: true},
+		{name: "map glob mismatch", predicate: MapGlob("Riverside*"), want: false},
+		{name: "version between", predicate: VersionBetween("41.78", "41.78.16"), want: true},
+		{name: "version below", predicate: VersionBetween("41.78.17", ""), want: false},
+		{name: "version above", predicate: VersionBetween("", "41.9"), want: false},
+		{name: "version unbounded", predicate: VersionBetween("", ""), want: true},
+		{name: "tags include", predicate: TagsInclude("hosted", "pvp"), want: true},
+		{name: "tags include missing", predicate: TagsInclude("hosted", "pve"), want: false},
+		{name: "tags exclude", predicate: TagsExclude("pve"), want: true},
+		{name: "tags exclude present", predicate: TagsExclude("pve", "hidden"), want: false},
+		{name: "all of", predicate: AllOf(SecureIs(true), OSIn("l")), want: true},
+		{name: "all of mismatch", predicate: AllOf(SecureIs(true), OSIn("w")), want: false},
+		{name: "all of nothing", predicate: AllOf(), want: true},
+		{name: "any of", predicate: AnyOf(SecureIs(false), OSIn("l")), want: true},
+		{name: "any of mismatch", predicate: AnyOf(SecureIs(false), OSIn("w")), want: false},
+		{name: "not", predicate: Not(SecureIs(false)), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.predicate(server))
+		})
+	}
+}
+
+func TestBotsRatioAtMost_EmptyServer(t *testing.T) {
+	assert.True(t, BotsRatioAtMost(0)(&Server{}))
+}
+
+func TestVersionBetween_PreRelease(t *testing.T) {
+	assert.False(t, VersionBetween("1.0", "")(&Server{Version: "1.0-beta"}))
+	assert.True(t, VersionBetween("1.0-alpha", "1.0")(&Server{Version: "1.0-beta"}))
+	assert.True(t, VersionBetween("", "1.0-beta")(&Server{Version: "v0.9"}))
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0.0.0", b: "1.0.0.0", want: 0},
+		{a: "1.0", b: "1.0.0.0", want: 0},
+		{a: "41.78.16", b: "41.9", want: 1},
+		{a: "41.9", b: "41.78.16", want: -1},
+		{a: "1.0.b", b: "1.0.a", want: 1},
+		{a: "1.0.9", b: "1.0.a", want: -1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CompareVersions(tt.a, tt.b), "CompareVersions(%q, %q)", tt.a, tt.b)
+	}
+}
+
+func TestClient_GetServerList_Match(t *testing.T) {
+	body := `{"response":{"servers":[` +
+		`{"addr":"127.0.0.1:1","name":"A","players":1,"max_players":10,"os":"w"},` +
+		`{"addr":"127.0.0.2:1","name":"B","players":5,"max_players":10,"os":"l"},` +
+		`{"addr":"127.0.0.3:1","name":"C","players":9,"max_players":10,"os":"l"}]}}`
+
+	client := NewClient(newConfig("http://steam.test"), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, body), nil
+	})))
+
+	filter := &GetServerListFilter{AppID: 108600, Match: []ServerPredicate{OSIn("l"), FreeSlotsAtLeast(2)}}
+
+	servers, err := client.GetServerList(filter)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "B", servers[0].Name)
+
+	var streamed []string
+
+	for server, err := range client.StreamServerList(context.Background(), filter) {
+		require.NoError(t, err)
+
+		streamed = append(streamed, server.Name)
+	}
+
+	assert.Equal(t, []string{"B"}, streamed)
+}
@@ -0,0 +1,104 @@
+package steamweb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// KeyParam is a query parameter holding api key.
+	KeyParam = "key"
+	// KeyHeader is a header holding api key when Config.KeyInHeader is set.
+	KeyHeader = "x-webapi-key"
+
+	redacted = "REDACTED"
+)
+
+// redactedError hides api keys in the message of the wrapped error.
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError removes keys from err message. *url.Error returned by
+// http.Client is copied with redacted URL, so errors.As returns a safe value.
+func redactError(err error, keys ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	if urlErr, ok := err.(*url.Error); ok { //nolint:errorlint // Only the top level error is copied.
+		safe := *urlErr
+		safe.URL = redactString(urlErr.URL, keys...)
+		err = &safe
+	}
+
+	msg := err.Error()
+	if safe := redactString(msg, keys...); safe != msg {
+		return &redactedError{err: err, msg: safe}
+	}
+
+	return err
+}
+
+func redactString(s string, keys ...string) string {
+	for _, key := range keys {
+		if key != "" {
+			s = strings.ReplaceAll(s, key, redacted)
+			s = strings.ReplaceAll(s, url.QueryEscape(key), redacted)
+		}
+	}
+
+	return s
+}
+
+// plainConfig has the same fields as Config but none of its methods.
+type plainConfig Config
+
+// masked returns a copy of cfg with api keys and proxy password masked.
+func (cfg Config) masked() plainConfig {
+	safe := plainConfig(cfg)
+
+	if safe.Key != "" {
+		safe.Key = maskKey(safe.Key)
+	}
+
+	if len(safe.Keys) != 0 {
+		safe.Keys = make([]string, len(cfg.Keys))
+		for i, key := range cfg.Keys {
+			safe.Keys[i] = maskKey(key)
+		}
+	}
+
+	if proxy, err := url.Parse(safe.Transport.Proxy); err == nil && safe.Transport.Proxy != "" {
+		safe.Transport.Proxy = proxy.Redacted()
+	}
+
+	return safe
+}
+
+// String returns Config with masked api keys, so it is safe to log.
+func (cfg Config) String() string {
+	return fmt.Sprintf("%+v", cfg.masked())
+}
+
+// GoString returns Config with masked api keys, so it is safe to log with %#v.
+func (cfg Config) GoString() string {
+	return fmt.Sprintf("%#v", cfg.masked())
+}
+
+// MarshalJSON encodes Config with masked api keys. Encoded Config can not
+// be used to create Client, it is meant for dumping settings.
+func (cfg Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cfg.masked())
+}
@@ -0,0 +1,172 @@
+package steamweb
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Region is a master server region code reported in Server.Region.
+//
+// See: https://developer.valvesoftware.com/wiki/Master_Server_Query_Protocol#Region_codes.
+type Region int
+
+// Region codes. Steam Web API reports the "rest of the world" region 0xFF
+// as a signed byte, so it is -1 in server lists.
+const (
+	RegionUSEast       Region = 0x00
+	RegionUSWest       Region = 0x01
+	RegionSouthAmerica Region = 0x02
+	RegionEurope       Region = 0x03
+	RegionAsia         Region = 0x04
+	RegionAustralia    Region = 0x05
+	RegionMiddleEast   Region = 0x06
+	RegionAfrica       Region = 0x07
+	RegionWorld        Region = -1
+)
+
+// Regions lists all known regions in the order of their codes.
+var Regions = []Region{
+	RegionUSEast, RegionUSWest, RegionSouthAmerica, RegionEurope,
+	RegionAsia, RegionAustralia, RegionMiddleEast, RegionAfrica, RegionWorld,
+}
+
+var regionNames = map[Region]string{
+	RegionUSEast:       "US East",
+	RegionUSWest:       "US West",
+	RegionSouthAmerica: "South America",
+	RegionEurope:       "Europe",
+	RegionAsia:         "Asia",
+	RegionAustralia:    "Australia",
+	RegionMiddleEast:   "Middle East",
+	RegionAfrica:       "Africa",
+	RegionWorld:        "World",
+}
+
+// ParseRegion returns the region by its name or code, names are matched
+// ignoring case, spaces and underscores.
+func ParseRegion(s string) (Region, error) {
+	if code, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+		region := Region(code).normalize()
+		if region.Known() {
+			return region, nil
+		}
+	}
+
+	name := regionKey(s)
+
+	for region, regionName := range regionNames {
+		if regionKey(regionName) == name {
+			return region, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: region: unknown region %q", ErrInvalidParam, s)
+}
+
+func regionKey(s string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(s))
+}
+
+// normalize converts the unsigned rest of the world code 0xFF to RegionWorld.
+func (r Region) normalize() Region {
+	if r == 0xFF { //nolint:mnd // Unsigned region byte.
+		return RegionWorld
+	}
+
+	return r
+}
+
+// Known reports whether the region code is one of Regions.
+func (r Region) Known() bool {
+	_, ok := regionNames[r.normalize()]
+
+	return ok
+}
+
+// Code returns the region byte used in master server queries.
+func (r Region) Code() byte {
+	return byte(r.normalize())
+}
+
+// String returns the region name or its code when the region is unknown.
+func (r Region) String() string {
+	if name, ok := regionNames[r.normalize()]; ok {
+		return name
+	}
+
+	return "Region(" + strconv.Itoa(int(r)) + ")"
+}
+
+// matchRegions reports whether the server is located in one of the regions.
+// Empty regions or RegionWorld among them match every server, as the master
+// server does for the rest of the world query.
+func matchRegions(server *Server, regions []Region) bool {
+	if len(regions) == 0 {
+		return true
+	}
+
+	for _, region := range regions {
+		region = region.normalize()
+		if region == RegionWorld || region == server.Region.normalize() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GroupByRegion groups servers by their regions keeping the order of servers.
+func GroupByRegion(servers []Server) map[Region][]Server {
+	groups := make(map[Region][]Server)
+
+	for i := range servers {
+		region := servers[i].Region.normalize()
+		groups[region] = append(groups[region], servers[i])
+	}
+
+	return groups
+}
+
+// RegionSummary is the population of servers in one region.
+type RegionSummary struct {
+	Region     Region `json:"region"`
+	Servers    int    `json:"servers"`
+	Players    int    `json:"players"`
+	MaxPlayers int    `json:"max_players"`
+	Bots       int    `json:"bots"`
+}
+
+// SummarizeByRegion counts servers and players per region. Summaries are
+// ordered by region code with RegionWorld last.
+func SummarizeByRegion(servers []Server) []RegionSummary {
+	summaries := make(map[Region]*RegionSummary)
+
+	for i := range servers {
+		region := servers[i].Region.normalize()
+
+		summary, ok := summaries[region]
+		if !ok {
+			summary = &RegionSummary{Region: region}
+			summaries[region] = summary
+		}
+
+		summary.Servers++
+		summary.Players += servers[i].Players
+		summary.MaxPlayers += servers[i].MaxPlayers
+		summary.Bots += servers[i].Bots
+	}
+
+	result := make([]RegionSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+
+	slices.SortFunc(result, func(a, b RegionSummary) int {
+		return cmp.Compare(a.Region.Code(), b.Region.Code())
+	})
+
+	return result
+}
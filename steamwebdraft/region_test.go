@@ -0,0 +1,104 @@
+package steamweb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegion(t *testing.T) {
+	assert.Equal(t, "Europe", RegionEurope.String())
+	assert.Equal(t, "World", RegionWorld.String())
+	assert.Equal(t, "World", Region(0xFF).String())
+	assert.Equal(t, "Region(42)", Region(42).String())
+
+	assert.Equal(t, byte(0xFF), RegionWorld.Code())
+	assert.Equal(t, byte(0x03), RegionEurope.Code())
+	assert.False(t, Region(42).Known())
+
+	for _, region := range Regions {
+		parsed, err := ParseRegion(region.String())
+		require.NoError(t, err)
+		assert.Equal(t, region, parsed)
+	}
+
+	parsed, err := ParseRegion("south_america")
+	require.NoError(t, err)
+	assert.Equal(t, RegionSouthAmerica, parsed)
+
+	parsed, err = ParseRegion("255")
+	require.NoError(t, err)
+	assert.Equal(t, RegionWorld, parsed)
+
+	_, err = ParseRegion("Antarctica")
+	require.ErrorIs(t, err, ErrInvalidParam)
+
+	var server Server
+	require.NoError(t, json.Unmarshal([]byte(`{"region":-1}`), &server))
+	assert.Equal(t, RegionWorld, server.Region)
+}
+
+func TestGetServerListFilter_Regions(t *testing.T) {
+	servers := []Server{
+		{Addr: "1", Region: RegionEurope, Players: 10, MaxPlayers: 32},
+		{Addr: "2", Region: RegionUSEast, Players: 4, MaxPlayers: 16, Bots: 1},
+		{Addr: "3", Region: RegionWorld, Players: 1, MaxPlayers: 8},
+		{Addr: "4", Region: RegionEurope, Players: 2, MaxPlayers: 10},
+		{Addr: "5", Region: 0xFF, Players: 3, MaxPlayers: 8},
+	}
+
+	addrs := func(servers []Server) []string {
+		result := make([]string, 0, len(servers))
+		for i := range servers {
+			result = append(result, servers[i].Addr)
+		}
+
+		return result
+	}
+
+	client := NewClient(newConfig(""))
+
+	tests := []struct {
+		name    string
+		regions []Region
+		want    []string
+	}{
+		{name: "all", regions: nil, want: []string{"1", "2", "5", "4", "3"}},
+		{name: "europe", regions: []Region{RegionEurope}, want: []string{"1", "4"}},
+		{name: "several", regions: []Region{RegionUSEast, RegionEurope}, want: []string{"1", "2", "4"}},
+		{name: "world", regions: []Region{RegionAsia, RegionWorld}, want: []string{"1", "2", "5", "4", "3"}},
+		{name: "none", regions: []Region{RegionAfrica}, want: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &GetServerListFilter{AppID: 108600, Regions: test.regions}
+			require.NoError(t, filter.Validate())
+
+			got := client.filterServers(append([]Server(nil), servers...), filter)
+			assert.Equal(t, test.want, addrs(got))
+		})
+	}
+
+	require.ErrorIs(t, (&GetServerListFilter{AppID: 108600, Regions: []Region{42}}).Validate(), ErrInvalidParam)
+
+	t.Run("group", func(t *testing.T) {
+		groups := GroupByRegion(servers)
+		assert.Len(t, groups, 3)
+		assert.Equal(t, []string{"1", "4"}, addrs(groups[RegionEurope]))
+		assert.Equal(t, []string{"3", "5"}, addrs(groups[RegionWorld]))
+	})
+
+	t.Run("summarize", func(t *testing.T) {
+		assert.Equal(t, []RegionSummary{
+			{Region: RegionUSEast, Servers: 1, Players: 4, MaxPlayers: 16, Bots: 1},
+			{Region: RegionEurope, Servers: 2, Players: 12, MaxPlayers: 42},
+			{Region: RegionWorld, Servers: 2, Players: 4, MaxPlayers: 16},
+		}, SummarizeByRegion(servers))
+	})
+
+	assert.True(t, RegionIn(RegionWorld)(&servers[4]))
+	assert.False(t, RegionIn(RegionWorld)(&servers[0]))
+}
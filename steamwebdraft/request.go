@@ -3,11 +3,43 @@ package steamweb
 import (
 	"errors"
 	"fmt"
+	"net/netip"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-var ErrRequiredParam = errors.New("param is required")
+var (
+	ErrRequiredParam     = errors.New("param is required")
+	ErrInvalidParam      = errors.New("param is invalid")
+	ErrConflictingParams = errors.New("params are conflicting")
+)
+
+// FilterError describes an invalid GetServerListFilter param.
+type FilterError struct {
+	// Param is the filter param name, e.g. "appid".
+	Param string
+
+	// Reason explains the problem, it may be empty.
+	Reason string
+
+	// Err is ErrRequiredParam, ErrInvalidParam or ErrConflictingParams.
+	Err error
+}
+
+func (e *FilterError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s: %s", e.Err, e.Param)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", e.Err, e.Param, e.Reason)
+}
+
+func (e *FilterError) Unwrap() error {
+	return e.Err
+}
 
 // GetServerListFilter represents the filter parameters used when querying game servers
 // from the Steam server browser. Each field corresponds to a specific filter that can
@@ -17,11 +49,11 @@ var ErrRequiredParam = errors.New("param is required")
 // See: https://developer.valvesoftware.com/wiki/Master_Server_Query_Protocol.
 type GetServerListFilter struct {
 	// NotOr is a special filter, specifies that servers matching any of the following [x]
-	// conditions should not be returned.
+	// conditions should not be returned. Conditions are formatted as \key\value, see FilterCondition.
 	// Usage: \nor\[x].
 	NotOr []string `json:"nor,omitempty"`
 	// NotAnd is a special filter, specifies that servers matching all of the following [x]
-	// conditions should not be returned.
+	// conditions should not be returned. Conditions are formatted as \key\value, see FilterCondition.
 	// Usage: \nand\[x].
 	NotAnd []string `json:"nand,omitempty"`
 	// Dedicated is a filter for servers running dedicated.
@@ -65,8 +97,13 @@ type GetServerListFilter struct {
 	// Usage: \white\1.
 	Whitelisted bool `json:"white,omitempty"`
 	// GameTypeTags is a filer for servers with all of the given tag(s) in sv_tags.
+	// It is also applied to returned servers, so games ignoring it are filtered the same way.
 	// Usage: \gametype\[tag,…].
 	GameTypeTags []string `json:"gametype,omitempty"`
+	// NotGameTypeTags is a filter for servers with none of the given tag(s) in sv_tags.
+	// It is also applied to returned servers, so games ignoring it are filtered the same way.
+	// Usage: \nor\[x]\gametype\[tag]….
+	NotGameTypeTags []string `json:"not_gametype,omitempty"`
 	// GameDataTags is a filer for servers with all of the given tag(s) in their ‘hidden’ tags (L4D2).
 	// Usage: \gamedata\[tag,…].
 	GameDataTags []string `json:"gamedata,omitempty"`
@@ -92,11 +129,24 @@ type GetServerListFilter struct {
 	NoHidden bool `json:"nohidden,omitempty"`
 	// NoDefaultServers is a custom filer for servers that has a name that differs from the default name.
 	NoDefaultServers bool `json:"no_default_servers,omitempty"`
+	// Regions is a custom filter for servers located in one of the regions.
+	// Steam Web API does not filter by region, master servers take it as the
+	// region byte of the query, see Region.Code. RegionWorld matches all servers.
+	Regions []Region `json:"region,omitempty"`
+	// Strict fails requests with *IgnoredFiltersError when a param is not
+	// supported by Steam for the app and cannot be emulated, see UnsupportedFiltersByApp.
+	// Such params are dropped and logged otherwise.
+	Strict bool `json:"strict,omitempty"`
+	// Match is a list of custom predicates, servers must match all of them.
+	Match []ServerPredicate `json:"-"`
+	// Sort orders returned servers, the default is DefaultSort.
+	Sort []SortOrder `json:"sort,omitempty"`
 	// Limit limits response.
 	Limit int `json:"limit,omitempty"`
 }
 
-// String converts fields to url part with params.
+// String converts fields to url part with params. All params are included,
+// Client leaves out params unsupported by the app, see UnsupportedFiltersByApp.
 func (g *GetServerListFilter) String() string { //nolint:funlen,cyclop // I don't care
 	query := `\appid\` + strconv.Itoa(g.AppID)
 
@@ -112,7 +162,6 @@ func (g *GetServerListFilter) String() string { //nolint:funlen,cyclop // I don'
 		query += `\gamedir\` + g.GameDir
 	}
 
-	// Not working in PZ.
 	if g.Map != "" {
 		query += `\map\` + g.Map
 	}
@@ -121,7 +170,6 @@ func (g *GetServerListFilter) String() string { //nolint:funlen,cyclop // I don'
 		query += `\linux\1`
 	}
 
-	// Not working in PZ.
 	if g.NoPassword {
 		query += `\password\0`
 	}
@@ -134,12 +182,10 @@ func (g *GetServerListFilter) String() string { //nolint:funlen,cyclop // I don'
 		query += `\full\1`
 	}
 
-	// Not working in PZ.
 	if g.Proxy {
 		query += `\proxy\1`
 	}
 
-	// Not working in PZ.
 	if g.NotAppID != 0 {
 		query += `\napp\` + strconv.Itoa(g.NotAppID)
 	}
@@ -148,7 +194,6 @@ func (g *GetServerListFilter) String() string { //nolint:funlen,cyclop // I don'
 		query += `\noplayers\1`
 	}
 
-	// Not working in PZ.
 	if g.Whitelisted {
 		query += `\white\1`
 	}
@@ -157,12 +202,18 @@ func (g *GetServerListFilter) String() string { //nolint:funlen,cyclop // I don'
 		query += `\gametype\` + strings.Join(g.GameTypeTags, `;`)
 	}
 
-	// Not working in PZ.
+	if len(g.NotGameTypeTags) != 0 {
+		query += `\nor\` + strconv.Itoa(len(g.NotGameTypeTags))
+
+		for _, tag := range g.NotGameTypeTags {
+			query += `\gametype\` + tag
+		}
+	}
+
 	if len(g.GameDataTags) != 0 {
 		query += `\gamedata\` + strings.Join(g.GameDataTags, `,`)
 	}
 
-	// Not working in PZ.
 	if len(g.GameDataOrTags) != 0 {
 		query += `\gamedataor\` + strings.Join(g.GameDataOrTags, `,`)
 	}
@@ -171,13 +222,191 @@ func (g *GetServerListFilter) String() string { //nolint:funlen,cyclop // I don'
 		query += `\name_match\*` + g.NameMatch + `*`
 	}
 
+	if g.VersionMatch != "" {
+		query += `\version_match\` + g.VersionMatch
+	}
+
+	if g.CollapseAddrHash {
+		query += `\collapse_addr_hash\1`
+	}
+
+	if g.GameAddr != "" {
+		query += `\gameaddr\` + g.GameAddr
+	}
+
+	if len(g.NotOr) != 0 {
+		query += `\nor\` + strconv.Itoa(len(g.NotOr)) + strings.Join(g.NotOr, "")
+	}
+
+	if len(g.NotAnd) != 0 {
+		query += `\nand\` + strconv.Itoa(len(g.NotAnd)) + strings.Join(g.NotAnd, "")
+	}
+
 	return query
 }
 
-func (g *GetServerListFilter) Validate() error {
-	if g.AppID == 0 {
-		return fmt.Errorf("%w: %s", ErrRequiredParam, "appid")
+// FilterCondition formats a condition of NotOr and NotAnd filters, e.g.
+// FilterCondition("map", "de_dust") is \map\de_dust.
+func FilterCondition(key, value string) string {
+	return `\` + key + `\` + value
+}
+
+// local reports whether the filter has conditions applied to returned servers.
+func (g *GetServerListFilter) local() bool {
+	return g.NoHidden || g.NoDefaultServers || len(g.GameTypeTags) != 0 || len(g.NotGameTypeTags) != 0 ||
+		len(g.Regions) != 0 || len(g.Match) != 0
+}
+
+// Matches reports whether the server matches all predicates of Match.
+func (g *GetServerListFilter) Matches(server *Server) bool {
+	for _, predicate := range g.Match {
+		if !predicate(server) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks the filter and reports all invalid params at once,
+// every error is *FilterError.
+func (g *GetServerListFilter) Validate() error { //nolint:funlen,cyclop // Flat list of checks.
+	var errs []error
+
+	invalid := func(param, format string, args ...any) {
+		errs = append(errs, &FilterError{Param: param, Reason: fmt.Sprintf(format, args...), Err: ErrInvalidParam})
+	}
+
+	conflicting := func(param, other string) {
+		errs = append(errs, &FilterError{Param: param, Reason: "conflicts with " + other, Err: ErrConflictingParams})
+	}
+
+	switch {
+	case g.AppID == 0:
+		errs = append(errs, &FilterError{Param: "appid", Err: ErrRequiredParam})
+	case g.AppID < 0:
+		invalid("appid", "negative value %d", g.AppID)
+	}
+
+	if g.NotAppID < 0 {
+		invalid("napp", "negative value %d", g.NotAppID)
+	}
+
+	if g.NotAppID != 0 && g.NotAppID == g.AppID {
+		conflicting("napp", "appid")
+	}
+
+	if g.Limit < 0 {
+		invalid("limit", "negative value %d", g.Limit)
+	}
+
+	if g.NotEmpty && g.NoPlayers {
+		conflicting("empty", "noplayers")
+	}
+
+	for param, value := range map[string]string{
+		"gamedir":       g.GameDir,
+		"map":           g.Map,
+		"name_match":    g.NameMatch,
+		"version_match": g.VersionMatch,
+		"gameaddr":      g.GameAddr,
+	} {
+		if err := validateFilterValue(value); err != nil {
+			invalid(param, "%v", err)
+		}
+	}
+
+	if g.GameAddr != "" {
+		if err := validateGameAddr(g.GameAddr); err != nil {
+			invalid("gameaddr", "%v", err)
+		}
+	}
+
+	for param, tags := range map[string][]string{
+		"gametype":     g.GameTypeTags,
+		"not_gametype": g.NotGameTypeTags,
+		"gamedata":     g.GameDataTags,
+		"gamedataor":   g.GameDataOrTags,
+	} {
+		for _, tag := range tags {
+			if err := validateTag(tag); err != nil {
+				invalid(param, "%v", err)
+			}
+		}
+	}
+
+	if slices.ContainsFunc(g.GameTypeTags, func(tag string) bool { return slices.Contains(g.NotGameTypeTags, tag) }) {
+		conflicting("not_gametype", "gametype")
+	}
+
+	for param, conditions := range map[string][]string{"nor": g.NotOr, "nand": g.NotAnd} {
+		for _, condition := range conditions {
+			if err := validateCondition(condition); err != nil {
+				invalid(param, "%v", err)
+			}
+		}
+	}
+
+	for _, region := range g.Regions {
+		if !region.Known() {
+			invalid("region", "unknown region %d", region)
+		}
+	}
+
+	for _, order := range g.Sort {
+		if err := order.Validate(); err != nil {
+			invalid("sort", "unknown key %q", order.Key)
+		}
+	}
+
+	slices.SortStableFunc(errs, func(a, b error) int {
+		return strings.Compare(a.(*FilterError).Param, b.(*FilterError).Param) //nolint:forcetypeassert // Only FilterError.
+	})
+
+	return errors.Join(errs...)
+}
+
+// validateFilterValue rejects characters breaking the filter string.
+func validateFilterValue(value string) error {
+	if i := strings.IndexFunc(value, func(r rune) bool { return r == '\\' || unicode.IsControl(r) }); i >= 0 {
+		r, _ := utf8.DecodeRuneInString(value[i:])
+
+		return fmt.Errorf("forbidden character %q", r)
+	}
+
+	return nil
+}
+
+func validateTag(tag string) error {
+	if strings.TrimSpace(tag) == "" {
+		return errors.New("empty tag")
+	}
+
+	if strings.ContainsAny(tag, ",;") {
+		return fmt.Errorf("tag %q contains a separator", tag)
+	}
+
+	return validateFilterValue(tag)
+}
+
+func validateGameAddr(addr string) error {
+	if _, err := netip.ParseAddr(addr); err == nil {
+		return nil
+	}
+
+	if _, err := netip.ParseAddrPort(addr); err != nil {
+		return fmt.Errorf("%q is not an ip or ip:port", addr)
 	}
 
 	return nil
 }
+
+// validateCondition checks \key\value condition of nor and nand filters.
+func validateCondition(condition string) error {
+	parts := strings.Split(condition, `\`)
+	if len(parts) != 3 || parts[0] != "" || parts[1] == "" || parts[2] == "" { //nolint:mnd // Empty prefix, key and value.
+		return fmt.Errorf("condition %q is not \\key\\value", condition)
+	}
+
+	return validateFilterValue(parts[2])
+}
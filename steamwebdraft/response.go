@@ -49,14 +49,14 @@ type (
 		GameDir    string `json:"gamedir"`
 		Version    string `json:"version"`
 		Product    string `json:"product"`
-		Region     int    `json:"region"`
+		Region     Region `json:"region"`
 		Players    int    `json:"players"`
 		MaxPlayers int    `json:"max_players"`
 		Bots       int    `json:"bots"`
 		Map        string `json:"map"`
 		Secure     bool   `json:"secure"`
 		Dedicated  bool   `json:"dedicated"`
-		OS         string `json:"os"`
+		OS         OS     `json:"os"`
 		GameType   string `json:"gametype"`
 	}
 )
@@ -0,0 +1,272 @@
+package steamweb
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// OS is a server platform reported in Server.OS.
+type OS string
+
+// Platforms reported by Steam.
+const (
+	OSWindows OS = "w"
+	OSLinux   OS = "l"
+	OSMacOS   OS = "m"
+)
+
+// ParseOS returns the platform by its code or name ignoring case. Legacy
+// "o" code of macOS servers is accepted too.
+func ParseOS(s string) (OS, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "w", "windows":
+		return OSWindows, nil
+	case "l", "linux":
+		return OSLinux, nil
+	case "m", "o", "mac", "macos", "osx":
+		return OSMacOS, nil
+	default:
+		return "", fmt.Errorf("%w: os: unknown platform %q", ErrInvalidParam, s)
+	}
+}
+
+// String returns the platform name or its code when the platform is unknown.
+func (o OS) String() string {
+	switch o.normalize() {
+	case OSWindows:
+		return "Windows"
+	case OSLinux:
+		return "Linux"
+	case OSMacOS:
+		return "macOS"
+	default:
+		return string(o)
+	}
+}
+
+// normalize returns the platform code of the legacy code or name, unknown
+// platforms are returned as is. Server.OS keeps the code reported by Steam.
+func (o OS) normalize() OS {
+	if platform, err := ParseOS(string(o)); err == nil {
+		return platform
+	}
+
+	return o
+}
+
+// SteamID is a 64 bit Steam ID. It is encoded as a decimal string in JSON
+// as Steam does.
+type SteamID uint64
+
+// ParseSteamID parses a 64 bit Steam ID in decimal form.
+func ParseSteamID(s string) (SteamID, error) {
+	id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: steamid: %q", ErrInvalidParam, s)
+	}
+
+	return SteamID(id), nil
+}
+
+// AccountID returns the lower 32 bits of the ID.
+func (id SteamID) AccountID() uint32 {
+	return uint32(id & 0xFFFFFFFF) //nolint:mnd // Account ID bits.
+}
+
+// Instance returns the 20 bit account instance.
+func (id SteamID) Instance() uint32 {
+	return uint32(id>>32) & 0xFFFFF //nolint:mnd // Instance bits.
+}
+
+// AccountType returns the 4 bit account type, game servers are 3 and
+// anonymous game servers are 4.
+func (id SteamID) AccountType() uint8 {
+	return uint8(id>>52) & 0xF //nolint:mnd // Account type bits.
+}
+
+// Universe returns the 8 bit universe, 1 is public.
+func (id SteamID) Universe() uint8 {
+	return uint8(id >> 56) //nolint:mnd // Universe bits.
+}
+
+// String returns the ID in decimal form.
+func (id SteamID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id SteamID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *SteamID) UnmarshalText(text []byte) error {
+	parsed, err := ParseSteamID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+
+	return nil
+}
+
+// Version is a game version parsed tolerantly: a leading "v" is ignored,
+// leading dot separated numbers are release numbers and the rest is a
+// pre-release suffix, e.g. "41.78.16-unstable" or "1.2b".
+type Version struct {
+	// Numbers are release numbers, e.g. 41, 78, 16.
+	Numbers []int
+
+	// Suffix is the text after release numbers without a separator.
+	Suffix string
+
+	raw string
+}
+
+// ParseVersion parses the version. It never fails, versions without
+// numbers have only Suffix.
+func ParseVersion(s string) Version {
+	v := Version{raw: s}
+	rest := strings.TrimSpace(s)
+
+	if len(rest) > 1 && (rest[0] == 'v' || rest[0] == 'V') && isDigit(rest[1]) {
+		rest = rest[1:]
+	}
+
+	for rest != "" && isDigit(rest[0]) {
+		i := 0
+		for i < len(rest) && isDigit(rest[i]) {
+			i++
+		}
+
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			break
+		}
+
+		v.Numbers = append(v.Numbers, n)
+		rest = rest[i:]
+
+		if len(rest) < 2 || rest[0] != '.' || !isDigit(rest[1]) {
+			break
+		}
+
+		rest = rest[1:]
+	}
+
+	v.Suffix = strings.TrimLeft(rest, ".-+_ ")
+
+	return v
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// Valid reports whether the version has release numbers.
+func (v Version) Valid() bool {
+	return len(v.Numbers) != 0
+}
+
+// Compare returns -1, 0 or +1. Release numbers are compared first, missing
+// numbers are zero. A version with a suffix is a pre-release and precedes
+// the same version without it, suffixes are compared as CompareVersions does.
+func (v Version) Compare(other Version) int {
+	for i := range max(len(v.Numbers), len(other.Numbers)) {
+		if result := cmp.Compare(versionNumber(v.Numbers, i), versionNumber(other.Numbers, i)); result != 0 {
+			return result
+		}
+	}
+
+	switch {
+	case v.Suffix == other.Suffix:
+		return 0
+	case v.Suffix == "":
+		return 1
+	case other.Suffix == "":
+		return -1
+	default:
+		return CompareVersions(v.Suffix, other.Suffix)
+	}
+}
+
+func versionNumber(numbers []int, i int) int {
+	if i < len(numbers) {
+		return numbers[i]
+	}
+
+	return 0
+}
+
+// Equal reports whether versions are equal ignoring formatting, "1.0" is
+// equal to "v1.0.0".
+func (v Version) Equal(other Version) bool {
+	return v.Compare(other) == 0
+}
+
+// String returns the version as it was parsed or formats Numbers and
+// Suffix of a constructed version.
+func (v Version) String() string {
+	if v.raw != "" {
+		return v.raw
+	}
+
+	parts := make([]string, 0, len(v.Numbers))
+	for _, n := range v.Numbers {
+		parts = append(parts, strconv.Itoa(n))
+	}
+
+	version := strings.Join(parts, ".")
+
+	if v.Suffix != "" && version != "" {
+		return version + "-" + v.Suffix
+	}
+
+	return version + v.Suffix
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(text []byte) error {
+	*v = ParseVersion(string(text))
+
+	return nil
+}
+
+// AddrPort returns parsed Addr, the query address of the server.
+func (s *Server) AddrPort() (netip.AddrPort, error) {
+	return netip.ParseAddrPort(s.Addr)
+}
+
+// GameAddrPort returns the address players connect to, it is the IP of
+// Addr with GamePort.
+func (s *Server) GameAddrPort() (netip.AddrPort, error) {
+	addr, err := s.AddrPort()
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+
+	if s.GamePort == 0 {
+		return addr, nil
+	}
+
+	return netip.AddrPortFrom(addr.Addr(), uint16(s.GamePort)), nil //nolint:gosec // Ports fit uint16.
+}
+
+// ParsedSteamID returns parsed SteamID.
+func (s *Server) ParsedSteamID() (SteamID, error) {
+	return ParseSteamID(s.SteamID)
+}
+
+// ParsedVersion returns parsed Version.
+func (s *Server) ParsedVersion() Version {
+	return ParseVersion(s.Version)
+}
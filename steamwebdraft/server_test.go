@@ -0,0 +1,152 @@
+package steamweb
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Parsed(t *testing.T) {
+	var server Server
+
+	require.NoError(t, json.Unmarshal([]byte(`{"addr":"127.0.0.1:16261","gameport":16262,"steamid":"90268762852129810","version":"41.78.16","os":"l"}`), &server))
+
+	addr, err := server.AddrPort()
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddrPort("127.0.0.1:16261"), addr)
+
+	addr, err = server.GameAddrPort()
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddrPort("127.0.0.1:16262"), addr)
+
+	id, err := server.ParsedSteamID()
+	require.NoError(t, err)
+	assert.Equal(t, SteamID(90268762852129810), id)
+	assert.Equal(t, uint8(1), id.Universe())
+	assert.Equal(t, uint8(4), id.AccountType())
+	assert.Equal(t, "90268762852129810", id.String())
+
+	assert.Equal(t, []int{41, 78, 16}, server.ParsedVersion().Numbers)
+	assert.Equal(t, OSLinux, server.OS)
+	assert.Equal(t, "Linux", server.OS.String())
+
+	data, err := json.Marshal(server)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"steamid":"90268762852129810"`)
+	assert.Contains(t, string(data), `"os":"l"`)
+
+	_, err = (&Server{Addr: "localhost"}).AddrPort()
+	require.Error(t, err)
+
+	_, err = (&Server{SteamID: "STEAM_0:1:1"}).ParsedSteamID()
+	require.ErrorIs(t, err, ErrInvalidParam)
+}
+
+func TestSteamID_JSON(t *testing.T) {
+	var value struct {
+		ID SteamID `json:"id"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"76561197960287930"}`), &value))
+	assert.Equal(t, uint32(22202), value.ID.AccountID())
+	assert.Equal(t, uint32(1), value.ID.Instance())
+	assert.Equal(t, uint8(1), value.ID.AccountType())
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"76561197960287930"}`, string(data))
+
+	require.Error(t, json.Unmarshal([]byte(`{"id":"x"}`), &value))
+}
+
+func TestParseOS(t *testing.T) {
+	for input, want := range map[string]OS{"w": OSWindows, "Linux": OSLinux, "o": OSMacOS, " macOS ": OSMacOS} {
+		got, err := ParseOS(input)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseOS("bsd")
+	require.ErrorIs(t, err, ErrInvalidParam)
+
+	assert.Equal(t, "x", OS("x").String())
+
+	var servers []Server
+	require.NoError(t, json.Unmarshal([]byte(`[{"os":"o"},{"os":"m"},{"os":"x"}]`), &servers))
+	assert.Equal(t, []OS{"o", OSMacOS, "x"}, []OS{servers[0].OS, servers[1].OS, servers[2].OS})
+	assert.Equal(t, "macOS", servers[0].OS.String())
+	assert.True(t, OSIn(OSMacOS)(&servers[0]))
+	assert.True(t, OSIn("o")(&servers[1]))
+	assert.False(t, OSIn(OSMacOS)(&servers[2]))
+
+	// Codes are kept as reported, so they survive a round trip.
+	data, err := json.Marshal(servers)
+	require.NoError(t, err)
+
+	var codes []struct {
+		OS string `json:"os"`
+	}
+
+	require.NoError(t, json.Unmarshal(data, &codes))
+	assert.Equal(t, "o", codes[0].OS)
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input   string
+		numbers []int
+		suffix  string
+	}{
+		{input: "1.0.0.0", numbers: []int{1, 0, 0, 0}},
+		{input: "v2.3", numbers: []int{2, 3}},
+		{input: " 41.78.16-unstable ", numbers: []int{41, 78, 16}, suffix: "unstable"},
+		{input: "1.2b", numbers: []int{1, 2}, suffix: "b"},
+		{input: "1.0.b", numbers: []int{1, 0}, suffix: "b"},
+		{input: "2458 build", numbers: []int{2458}, suffix: "build"},
+		{input: "beta", suffix: "beta"},
+		{input: ""},
+	}
+
+	for _, tt := range tests {
+		v := ParseVersion(tt.input)
+		assert.Equal(t, tt.numbers, v.Numbers, tt.input)
+		assert.Equal(t, tt.suffix, v.Suffix, tt.input)
+		assert.Equal(t, tt.input, v.String())
+	}
+
+	assert.Equal(t, "1.2-rc.1", Version{Numbers: []int{1, 2}, Suffix: "rc.1"}.String())
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0", b: "v1.0.0", want: 0},
+		{a: "41.78.16", b: "41.9", want: 1},
+		{a: "41.78-beta", b: "41.78", want: -1},
+		{a: "41.78", b: "41.78-beta", want: 1},
+		{a: "1.0-rc.2", b: "1.0-rc.10", want: -1},
+		{a: "2", b: "10", want: -1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ParseVersion(tt.a).Compare(ParseVersion(tt.b)), "%q.Compare(%q)", tt.a, tt.b)
+	}
+
+	assert.True(t, ParseVersion("1.0").Equal(ParseVersion("1.0.0")))
+
+	var value struct {
+		Version Version `json:"version"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"version":"v1.2.3"}`), &value))
+	assert.Equal(t, []int{1, 2, 3}, value.Version.Numbers)
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"v1.2.3"}`, string(data))
+}
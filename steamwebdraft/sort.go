@@ -0,0 +1,128 @@
+package steamweb
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SortKey is a server field used for sorting.
+type SortKey string
+
+// Sort keys.
+const (
+	SortByPlayers   SortKey = "players"
+	SortByFreeSlots SortKey = "free_slots"
+	SortByName      SortKey = "name"
+	SortByPing      SortKey = "ping"
+	SortByVersion   SortKey = "version"
+)
+
+// SortOrder is one key of server list sorting.
+type SortOrder struct {
+	Key SortKey `json:"key"`
+
+	// Desc sorts in descending order.
+	Desc bool `json:"desc,omitempty"`
+
+	// Ping returns latency of the server for SortByPing, Steam does not
+	// report it. Servers are not reordered by ping without it.
+	Ping func(server *Server) time.Duration `json:"-"`
+}
+
+// DefaultSort sorts servers by players in descending order.
+var DefaultSort = []SortOrder{{Key: SortByPlayers, Desc: true}}
+
+// Validate checks that the sort key is known.
+func (o SortOrder) Validate() error {
+	switch o.Key {
+	case SortByPlayers, SortByFreeSlots, SortByName, SortByPing, SortByVersion:
+		return nil
+	default:
+		return fmt.Errorf("%w: sort: unknown key %q", ErrInvalidParam, o.Key)
+	}
+}
+
+// sortValue is a value of the sort key of a server, numeric keys use N and
+// text keys use S.
+type sortValue struct {
+	N int64  `json:"n,omitempty"`
+	S string `json:"s,omitempty"`
+}
+
+// value returns the sort key value of the server, names are lowercased.
+func (o SortOrder) value(server *Server) sortValue {
+	switch o.Key {
+	case SortByPlayers:
+		return sortValue{N: int64(server.Players)}
+	case SortByFreeSlots:
+		return sortValue{N: int64(server.MaxPlayers - server.Players)}
+	case SortByName:
+		return sortValue{S: strings.ToLower(server.Name)}
+	case SortByPing:
+		if o.Ping != nil {
+			return sortValue{N: int64(o.Ping(server))}
+		}
+	case SortByVersion:
+		return sortValue{S: server.Version}
+	}
+
+	return sortValue{}
+}
+
+// compareValues compares sort key values in the direction of the order.
+func (o SortOrder) compareValues(a, b sortValue) int {
+	var result int
+
+	if o.Key == SortByVersion {
+		result = ParseVersion(a.S).Compare(ParseVersion(b.S))
+	} else {
+		result = cmp.Or(cmp.Compare(a.N, b.N), strings.Compare(a.S, b.S))
+	}
+
+	if o.Desc {
+		return -result
+	}
+
+	return result
+}
+
+// compare compares servers by the key, names are compared case-insensitively.
+func (o SortOrder) compare(a, b *Server) int {
+	return o.compareValues(o.value(a), o.value(b))
+}
+
+// SortServers sorts servers by the orders, DefaultSort is used without them.
+// Servers equal by every key are ordered by Addr and SteamID, so the order
+// does not change between calls.
+func SortServers(servers []Server, orders ...SortOrder) {
+	orders = sortOrders(orders)
+
+	slices.SortStableFunc(servers, func(a, b Server) int {
+		return compareServers(&a, &b, orders)
+	})
+}
+
+func sortOrders(orders []SortOrder) []SortOrder {
+	if len(orders) == 0 {
+		return DefaultSort
+	}
+
+	return orders
+}
+
+func compareServers(a, b *Server, orders []SortOrder) int {
+	for _, order := range orders {
+		if result := order.compare(a, b); result != 0 {
+			return result
+		}
+	}
+
+	if result := strings.Compare(a.Addr, b.Addr); result != 0 {
+		return result
+	}
+
+	return strings.Compare(a.SteamID, b.SteamID)
+}
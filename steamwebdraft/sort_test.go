@@ -0,0 +1,220 @@
+package steamweb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serverAddrs(servers []Server) []string {
+	addrs := make([]string, len(servers))
+	for i := range servers {
+		addrs[i] = servers[i].Addr
+	}
+
+	return addrs
+}
+
+func TestSortServers(t *testing.T) {
+	servers := func() []Server {
+		return []Server{
+			{Addr: "127.0.0.3:1", Name: "bravo", Players: 5, MaxPlayers: 10, Version: "41.9"},
+			{Addr: "127.0.0.1:1", Name: "Alpha", Players: 5, MaxPlayers: 20, Version: "41.78.16"},
+			{Addr: "127.0.0.2:1", Name: "charlie", Players: 8, MaxPlayers: 10, Version: "41.78.16"},
+			{Addr: "127.0.0.4:1", Name: "alpha", Players: 0, MaxPlayers: 10, Version: "41.78"},
+		}
+	}
+
+	pings := map[string]time.Duration{"127.0.0.1:1": 90, "127.0.0.2:1": 10, "127.0.0.3:1": 50, "127.0.0.4:1": 10}
+	ping := func(server *Server) time.Duration { return pings[server.Addr] }
+
+	tests := []struct {
+		name   string
+		orders []SortOrder
+		want   []string
+	}{
+		{name: "default", want: []string{"127.0.0.2:1", "127.0.0.1:1", "127.0.0.3:1", "127.0.0.4:1"}},
+		{name: "players ascending", orders: []SortOrder{{Key: SortByPlayers}}, want: []string{"127.0.0.4:1", "127.0.0.1:1", "127.0.0.3:1", "127.0.0.2:1"}},
+		{name: "free slots", orders: []SortOrder{{Key: SortByFreeSlots, Desc: true}}, want: []string{"127.0.0.1:1", "127.0.0.4:1", "127.0.0.3:1", "127.0.0.2:1"}},
+		{name: "name ignores case", orders: []SortOrder{{Key: SortByName}}, want: []string{"127.0.0.1:1", "127.0.0.4:1", "127.0.0.3:1", "127.0.0.2:1"}},
+		{name: "ping", orders: []SortOrder{{Key: SortByPing, Ping: ping}}, want: []string{"127.0.0.2:1", "127.0.0.4:1", "127.0.0.3:1", "127.0.0.1:1"}},
+		{name: "ping without func", orders: []SortOrder{{Key: SortByPing}}, want: []string{"127.0.0.1:1", "127.0.0.2:1", "127.0.0.3:1", "127.0.0.4:1"}},
+		{name: "version", orders: []SortOrder{{Key: SortByVersion, Desc: true}}, want: []string{"127.0.0.1:1", "127.0.0.2:1", "127.0.0.4:1", "127.0.0.3:1"}},
+		{
+			name:   "multiple keys",
+			orders: []SortOrder{{Key: SortByVersion, Desc: true}, {Key: SortByPlayers, Desc: true}},
+			want:   []string{"127.0.0.2:1", "127.0.0.1:1", "127.0.0.4:1", "127.0.0.3:1"},
+		},
+		{
+			name:   "multiple keys with direction",
+			orders: []SortOrder{{Key: SortByPlayers, Desc: true}, {Key: SortByName, Desc: true}},
+			want:   []string{"127.0.0.2:1", "127.0.0.3:1", "127.0.0.1:1", "127.0.0.4:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := servers()
+			SortServers(list, tt.orders...)
+			assert.Equal(t, tt.want, serverAddrs(list))
+		})
+	}
+
+	t.Run("pre-release version", func(t *testing.T) {
+		list := []Server{
+			{Addr: "127.0.0.1:1", Version: "1.0"},
+			{Addr: "127.0.0.2:1", Version: "1.0-beta"},
+			{Addr: "127.0.0.3:1", Version: "0.9"},
+		}
+		SortServers(list, SortOrder{Key: SortByVersion})
+		assert.Equal(t, []string{"127.0.0.3:1", "127.0.0.2:1", "127.0.0.1:1"}, serverAddrs(list))
+	})
+}
+
+func TestSortOrder_Validate(t *testing.T) {
+	require.NoError(t, SortOrder{Key: SortByName}.Validate())
+	require.ErrorIs(t, SortOrder{Key: "map"}.Validate(), ErrInvalidParam)
+	require.ErrorIs(t, (&GetServerListFilter{AppID: 1, Sort: []SortOrder{{}}}).Validate(), ErrInvalidParam)
+}
+
+func TestPaginate(t *testing.T) {
+	servers := make([]Server, 10)
+	for i := range servers {
+		servers[i] = Server{Addr: fmt.Sprintf("127.0.0.%d:1", i), Players: i % 3}
+	}
+
+	t.Run("offset", func(t *testing.T) {
+		page, err := Paginate(servers, Page{Offset: 8, Size: 4})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"127.0.0.6:1", "127.0.0.9:1"}, serverAddrs(page.Servers))
+		assert.Equal(t, 8, page.Offset)
+		assert.Equal(t, 10, page.Total)
+		assert.Empty(t, page.NextCursor)
+
+		page, err = Paginate(servers, Page{Offset: 20, Size: 4})
+		require.NoError(t, err)
+		assert.Empty(t, page.Servers)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		page, err := Paginate(servers, Page{})
+		require.NoError(t, err)
+		assert.Len(t, page.Servers, 10)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		var got []string
+
+		page := Page{Size: 3}
+
+		for range 10 {
+			result, err := Paginate(servers, page)
+			require.NoError(t, err)
+
+			got = append(got, serverAddrs(result.Servers)...)
+
+			if result.NextCursor == "" {
+				break
+			}
+
+			page.Cursor = result.NextCursor
+		}
+
+		sorted := append([]Server(nil), servers...)
+		SortServers(sorted)
+		assert.Equal(t, serverAddrs(sorted), got)
+	})
+
+	t.Run("cursor is stable", func(t *testing.T) {
+		first, err := Paginate(servers, Page{Size: 3})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"127.0.0.2:1", "127.0.0.5:1", "127.0.0.8:1"}, serverAddrs(first.Servers))
+
+		// The last server of the first page is gone and a new one
+		// appeared at the top, the next page still continues after it.
+		changed := append([]Server{{Addr: "127.0.0.99:1", Players: 10}}, servers[:8]...)
+		changed = append(changed, servers[9])
+
+		next, err := Paginate(changed, Page{Size: 3, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"127.0.0.1:1", "127.0.0.4:1", "127.0.0.7:1"}, serverAddrs(next.Servers))
+	})
+
+	t.Run("cursor by ping", func(t *testing.T) {
+		// Ping depends on a field the cursor does not keep.
+		order := SortOrder{Key: SortByPing, Ping: func(server *Server) time.Duration {
+			return time.Duration(100-server.GamePort) * time.Millisecond
+		}}
+
+		byPing := make([]Server, len(servers))
+		for i := range servers {
+			byPing[i] = servers[i]
+			byPing[i].GamePort = i
+		}
+
+		var got []string
+
+		page := Page{Size: 3}
+
+		for range 10 {
+			result, err := Paginate(byPing, page, order)
+			require.NoError(t, err)
+
+			got = append(got, serverAddrs(result.Servers)...)
+
+			if result.NextCursor == "" {
+				break
+			}
+
+			page.Cursor = result.NextCursor
+		}
+
+		sorted := append([]Server(nil), byPing...)
+		SortServers(sorted, order)
+		assert.Equal(t, serverAddrs(sorted), got)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		first, err := Paginate(servers, Page{Size: 3})
+		require.NoError(t, err)
+
+		_, err = Paginate(servers, Page{Cursor: first.NextCursor}, SortOrder{Key: SortByName})
+		require.ErrorIs(t, err, ErrInvalidParam)
+
+		_, err = Paginate(servers, Page{Cursor: "not a cursor"})
+		require.ErrorIs(t, err, ErrInvalidParam)
+
+		_, err = Paginate(servers, Page{Offset: -1})
+		require.ErrorIs(t, err, ErrInvalidParam)
+	})
+}
+
+func TestClient_GetServerListPage(t *testing.T) {
+	body := `{"response":{"servers":[` +
+		`{"addr":"127.0.0.1:1","name":"b","players":1},` +
+		`{"addr":"127.0.0.2:1","name":"a","players":5},` +
+		`{"addr":"127.0.0.3:1","name":"c","players":9}]}}`
+
+	client := NewClient(newConfig("http://steam.test"), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, body), nil
+	})))
+
+	filter := &GetServerListFilter{AppID: 108600, Sort: []SortOrder{{Key: SortByName}}}
+
+	page, err := client.GetServerListPage(context.Background(), filter, Page{Size: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.2:1", "127.0.0.1:1"}, serverAddrs(page.Servers))
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = client.GetServerListPage(context.Background(), filter, Page{Size: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.3:1"}, serverAddrs(page.Servers))
+	assert.Equal(t, 2, page.Offset)
+	assert.Empty(t, page.NextCursor)
+}
@@ -0,0 +1,135 @@
+// Package steamwebotel traces Steam Web API client calls with OpenTelemetry.
+//
+// It is a separate package, so the core client does not depend on OpenTelemetry:
+//
+//	client := steamweb.NewClient(cfg, steamweb.WithInstrumenter(steamwebotel.NewTracer()))
+//
+// Every Client method call gets a span, requests sent to Steam are recorded
+// as span events. Api keys are never recorded, even masked.
+package steamwebotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+)
+
+// ScopeName is the instrumentation scope name of created tracers.
+const ScopeName = "github.com/gorcon/steamweb/steamwebdraft/steamwebotel"
+
+// Span attributes.
+const (
+	InterfaceKey  = attribute.Key("steamweb.interface")
+	MethodKey     = attribute.Key("steamweb.method")
+	VersionKey    = attribute.Key("steamweb.version")
+	AttemptKey    = attribute.Key("steamweb.attempt")
+	RetriesKey    = attribute.Key("steamweb.retries")
+	ItemsKey      = attribute.Key("steamweb.items")
+	ErrorClassKey = attribute.Key("steamweb.error_class")
+	DurationKey   = attribute.Key("steamweb.duration_seconds")
+
+	ResponseBytesKey = attribute.Key("http.response.body.size")
+
+	HTTPMethodKey     = attribute.Key("http.request.method")
+	HTTPStatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// Tracer implements steamweb.CallInstrumenter.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ steamweb.CallInstrumenter = (*Tracer)(nil)
+
+// Option configures Tracer.
+type Option func(*config)
+
+type config struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider sets provider of the tracer.
+// The default is the global provider returned by otel.GetTracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// NewTracer creates Tracer.
+func NewTracer(opts ...Option) *Tracer {
+	cfg := config{provider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Tracer{tracer: cfg.provider.Tracer(ScopeName)}
+}
+
+// CallStarted implements steamweb.CallInstrumenter. It starts span named
+// like "ISteamUser/GetPlayerBans".
+func (t *Tracer) CallStarted(ctx context.Context, info steamweb.CallInfo) context.Context {
+	ctx, _ = t.tracer.Start(ctx, info.Interface+"/"+info.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			InterfaceKey.String(info.Interface),
+			MethodKey.String(info.Method),
+			VersionKey.Int(info.Version),
+			HTTPMethodKey.String(info.HTTPMethod),
+		),
+	)
+
+	return ctx
+}
+
+// CallFinished implements steamweb.CallInstrumenter. It ends the span.
+func (t *Tracer) CallFinished(ctx context.Context, _ steamweb.CallInfo, stats steamweb.CallStats) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	span.SetAttributes(RetriesKey.Int(max(stats.Attempts-1, 0)))
+
+	if stats.StatusCode != 0 {
+		span.SetAttributes(HTTPStatusCodeKey.Int(stats.StatusCode))
+	}
+
+	if stats.Items >= 0 {
+		span.SetAttributes(ItemsKey.Int(stats.Items))
+	}
+
+	if stats.Err != nil {
+		class := steamweb.ErrorClass(stats.Err)
+
+		span.SetAttributes(ErrorClassKey.String(class))
+		span.RecordError(stats.Err)
+		span.SetStatus(codes.Error, class)
+	}
+}
+
+// RequestStarted implements steamweb.Instrumenter.
+func (t *Tracer) RequestStarted(ctx context.Context, _ steamweb.RequestInfo) context.Context {
+	return ctx
+}
+
+// RequestFinished implements steamweb.Instrumenter. It adds "request" event
+// to the span of the call.
+func (t *Tracer) RequestFinished(ctx context.Context, info steamweb.RequestInfo, stats steamweb.RequestStats) {
+	attrs := []attribute.KeyValue{AttemptKey.Int(info.Attempt)}
+
+	if stats.StatusCode != 0 {
+		attrs = append(attrs, HTTPStatusCodeKey.Int(stats.StatusCode))
+	}
+
+	if stats.Err != nil {
+		attrs = append(attrs, ErrorClassKey.String(steamweb.ErrorClass(stats.Err)))
+	}
+
+	attrs = append(attrs, DurationKey.Float64(stats.Duration.Seconds()), ResponseBytesKey.Int64(stats.Bytes))
+
+	trace.SpanFromContext(ctx).AddEvent("request", trace.WithAttributes(attrs...))
+}
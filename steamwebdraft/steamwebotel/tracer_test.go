@@ -0,0 +1,101 @@
+package steamwebotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+	"github.com/gorcon/steamweb/steamwebdraft/steamwebotel"
+)
+
+func TestTracer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ISteamUser/GetFriendList/v1":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Query().Get("key") == "rejected-key":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"players":[{"SteamId":"1"},{"SteamId":"2"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := steamwebotel.NewTracer(steamwebotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	client := steamweb.NewClient(
+		&steamweb.Config{Keys: []string{"rejected-key", "accepted-key"}, URL: ts.URL},
+		steamweb.WithInstrumenter(tracer),
+	)
+
+	t.Run("success after retry", func(t *testing.T) {
+		bans, err := client.GetPlayerBans("1", "2")
+		require.NoError(t, err)
+		require.Len(t, bans, 2)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+
+		span := spans[0]
+		assert.Equal(t, "ISteamUser/GetPlayerBans", span.Name())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+
+		attrs := attribute.NewSet(span.Attributes()...)
+		assertAttr(t, attrs, steamwebotel.InterfaceKey, "ISteamUser")
+		assertAttr(t, attrs, steamwebotel.MethodKey, "GetPlayerBans")
+		assertAttr(t, attrs, steamwebotel.VersionKey, int64(1))
+		assertAttr(t, attrs, steamwebotel.HTTPStatusCodeKey, int64(200))
+		assertAttr(t, attrs, steamwebotel.RetriesKey, int64(1))
+		assertAttr(t, attrs, steamwebotel.ItemsKey, int64(2))
+
+		require.Len(t, span.Events(), 2)
+		assert.Equal(t, "request", span.Events()[0].Name)
+
+		for _, kv := range span.Attributes() {
+			assert.NotContains(t, kv.Value.Emit(), "-key")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := client.Call(context.Background(), "ISteamUser", "GetFriendList", 1, nil, nil)
+		require.ErrorIs(t, err, steamweb.ErrWrongStatusCode)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		span := spans[1]
+		assert.Equal(t, codes.Error, span.Status().Code)
+
+		attrs := attribute.NewSet(span.Attributes()...)
+		assertAttr(t, attrs, steamwebotel.HTTPStatusCodeKey, int64(401))
+		assertAttr(t, attrs, steamwebotel.ErrorClassKey, steamweb.ErrorClassStatus)
+
+		_, ok := attrs.Value(steamwebotel.ItemsKey)
+		assert.False(t, ok)
+
+		for _, event := range span.Events() {
+			for _, kv := range event.Attributes {
+				assert.False(t, strings.Contains(kv.Value.Emit(), "accepted-key"))
+			}
+		}
+	})
+}
+
+func assertAttr(t *testing.T, attrs attribute.Set, key attribute.Key, want any) {
+	t.Helper()
+
+	value, ok := attrs.Value(key)
+	require.True(t, ok, "attribute %s is not set", key)
+	assert.Equal(t, want, value.AsInterface(), "attribute %s", key)
+}
@@ -0,0 +1,124 @@
+// Package steamwebprom exposes Steam Web API client metrics as Prometheus collectors.
+//
+// It is a separate package, so the core client does not depend on Prometheus:
+//
+//	collector := steamwebprom.NewCollector("myapp")
+//	prometheus.MustRegister(collector)
+//
+//	client := steamweb.NewClient(cfg, steamweb.WithInstrumenter(collector))
+package steamwebprom
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+)
+
+const subsystem = "steamweb"
+
+// DefaultBuckets are request duration histogram buckets in seconds.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Collector implements steamweb.Instrumenter and prometheus.Collector.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	bytes    *prometheus.CounterVec
+	inFlight *prometheus.GaugeVec
+	keyCalls *prometheus.CounterVec
+}
+
+var (
+	_ steamweb.Instrumenter = (*Collector)(nil)
+	_ prometheus.Collector  = (*Collector)(nil)
+)
+
+// NewCollector creates Collector with metrics prefixed with namespace_steamweb_.
+// Metrics are:
+//
+//   - requests_total{interface,method,code,error} counts requests sent to Steam,
+//     every request consumes the api key quota;
+//   - request_duration_seconds{interface,method} observes request latencies;
+//   - response_bytes_total{interface,method} counts read response bytes;
+//   - requests_in_flight{interface,method} shows requests waiting for a response;
+//   - key_requests_total{key} counts requests per masked api key.
+func NewCollector(namespace string) *Collector {
+	labels := []string{"interface", "method"}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to Steam Web API.",
+		}, append(labels, "code", "error")),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of requests sent to Steam Web API.",
+			Buckets:   DefaultBuckets,
+		}, labels),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "response_bytes_total",
+			Help:      "Size of response bodies read from Steam Web API.",
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_in_flight",
+			Help:      "Number of requests waiting for Steam Web API response.",
+		}, labels),
+		keyCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "key_requests_total",
+			Help:      "Number of requests sent to Steam Web API per masked api key.",
+		}, []string{"key"}),
+	}
+}
+
+// RequestStarted implements steamweb.Instrumenter.
+func (c *Collector) RequestStarted(ctx context.Context, info steamweb.RequestInfo) context.Context {
+	c.inFlight.WithLabelValues(info.Interface, info.Method).Inc()
+	c.keyCalls.WithLabelValues(info.Key).Inc()
+
+	return ctx
+}
+
+// RequestFinished implements steamweb.Instrumenter.
+func (c *Collector) RequestFinished(_ context.Context, info steamweb.RequestInfo, stats steamweb.RequestStats) {
+	c.inFlight.WithLabelValues(info.Interface, info.Method).Dec()
+
+	code := ""
+	if stats.StatusCode != 0 {
+		code = strconv.Itoa(stats.StatusCode)
+	}
+
+	c.requests.WithLabelValues(info.Interface, info.Method, code, steamweb.ErrorClass(stats.Err)).Inc()
+	c.duration.WithLabelValues(info.Interface, info.Method).Observe(stats.Duration.Seconds())
+	c.bytes.WithLabelValues(info.Interface, info.Method).Add(float64(stats.Bytes))
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.bytes.Describe(ch)
+	c.inFlight.Describe(ch)
+	c.keyCalls.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.bytes.Collect(ch)
+	c.inFlight.Collect(ch)
+	c.keyCalls.Collect(ch)
+}
@@ -0,0 +1,68 @@
+package steamwebprom_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	steamweb "github.com/gorcon/steamweb/steamwebdraft"
+	"github.com/gorcon/steamweb/steamwebdraft/steamwebprom"
+)
+
+func TestCollector(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") == "rejected-key" {
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.Write([]byte(`{"players":[]}`))
+	}))
+	defer ts.Close()
+
+	collector := steamwebprom.NewCollector("test")
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	client := steamweb.NewClient(
+		&steamweb.Config{Keys: []string{"rejected-key", "accepted-key"}, URL: ts.URL},
+		steamweb.WithInstrumenter(collector),
+	)
+
+	_, err := client.GetPlayerBans("1")
+	require.NoError(t, err)
+
+	expected := `
+# HELP test_steamweb_key_requests_total Number of requests sent to Steam Web API per masked api key.
+# TYPE test_steamweb_key_requests_total counter
+test_steamweb_key_requests_total{key="ac****ey"} 1
+test_steamweb_key_requests_total{key="re****ey"} 1
+# HELP test_steamweb_requests_in_flight Number of requests waiting for Steam Web API response.
+# TYPE test_steamweb_requests_in_flight gauge
+test_steamweb_requests_in_flight{interface="ISteamUser",method="GetPlayerBans"} 0
+# HELP test_steamweb_requests_total Number of requests sent to Steam Web API.
+# TYPE test_steamweb_requests_total counter
+test_steamweb_requests_total{code="200",error="",interface="ISteamUser",method="GetPlayerBans"} 1
+test_steamweb_requests_total{code="429",error="status",interface="ISteamUser",method="GetPlayerBans"} 1
+# HELP test_steamweb_response_bytes_total Size of response bodies read from Steam Web API.
+# TYPE test_steamweb_response_bytes_total counter
+test_steamweb_response_bytes_total{interface="ISteamUser",method="GetPlayerBans"} 14
+`
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"test_steamweb_key_requests_total",
+		"test_steamweb_requests_in_flight",
+		"test_steamweb_requests_total",
+		"test_steamweb_response_bytes_total",
+	))
+
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "test_steamweb_request_duration_seconds"))
+}
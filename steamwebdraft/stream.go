@@ -0,0 +1,157 @@
+package steamweb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+var ErrUnexpectedResponse = errors.New("unexpected response")
+
+// StreamServerList is like GetServerListContext but decodes servers one by
+// one while the response is read, so memory usage does not grow with the
+// number of servers. Servers are yielded in the order of the response, they
+// are not sorted by players. Custom filters are applied.
+//
+// The response is neither cached nor shared with identical calls. Body larger
+// than Config.MaxStreamSize fails with ErrResponseTooLarge. An error is yielded
+// once, after that the iteration stops. Breaking the loop closes the response:
+//
+//	for server, err := range client.StreamServerList(ctx, filter) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(server.Name)
+//	}
+func (c *Client) StreamServerList(ctx context.Context, filter *GetServerListFilter) iter.Seq2[Server, error] {
+	return func(yield func(Server, error) bool) {
+		// Return empty servers list with disabled client.
+		if c.config.Disabled {
+			return
+		}
+
+		remote, local, _, err := c.planFilter(ctx, filter)
+		if err != nil {
+			yield(Server{}, err)
+
+			return
+		}
+		req := newServerListRequest(remote)
+
+		err = c.instrumentCall(ctx, req, func(ctx context.Context) (int, error) {
+			items := 0
+
+			err := c.sendRequestFunc(ctx, req, func(body io.Reader) error {
+				return decodeServers(&maxBytesReader{r: body, limit: c.config.MaxStreamSize}, func(server *Server) bool {
+					if c.skipServer(server, local) {
+						return true
+					}
+
+					items++
+
+					return yield(*server, nil)
+				})
+			})
+
+			return items, err
+		})
+		if err != nil {
+			yield(Server{}, err)
+		}
+	}
+}
+
+// decodeServers reads GetServerList response token by token and calls fn for
+// every server until it returns false.
+func decodeServers(r io.Reader, fn func(server *Server) bool) error {
+	dec := json.NewDecoder(r)
+	stopped := false
+
+	return decodeObject(dec, func(name string) (bool, error) {
+		if name != "response" {
+			return true, skipValue(dec)
+		}
+
+		err := decodeObject(dec, func(name string) (bool, error) {
+			if name != "servers" {
+				return true, skipValue(dec)
+			}
+
+			return decodeArray(dec, func() (bool, error) {
+				var server Server
+				if err := dec.Decode(&server); err != nil {
+					return false, err
+				}
+
+				stopped = !fn(&server)
+
+				return !stopped, nil
+			})
+		})
+
+		return !stopped, err
+	})
+}
+
+// decodeObject calls fn for every field name of JSON object, fn must
+// consume the value. It stops when fn returns false.
+func decodeObject(dec *json.Decoder, fn func(name string) (bool, error)) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		name, _ := token.(string)
+
+		next, err := fn(name)
+		if err != nil || !next {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// decodeArray calls fn for every element of JSON array, fn must consume
+// the element. It reports false when fn stopped the iteration.
+func decodeArray(dec *json.Decoder, fn func() (bool, error)) (bool, error) {
+	if err := expectDelim(dec, '['); err != nil {
+		return false, err
+	}
+
+	for dec.More() {
+		next, err := fn()
+		if err != nil || !next {
+			return false, err
+		}
+	}
+
+	return true, expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("%w: expected %q, got %v", ErrUnexpectedResponse, delim, token)
+	}
+
+	return nil
+}
+
+func skipValue(dec *json.Decoder) error {
+	var value json.RawMessage
+
+	return dec.Decode(&value)
+}
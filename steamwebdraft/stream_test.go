@@ -0,0 +1,117 @@
+package steamweb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serverListBody(n int) string {
+	servers := make([]string, n)
+	for i := range servers {
+		servers[i] = fmt.Sprintf(`{"addr":"127.0.0.%d:16261","name":"Server %d","players":%d,"gametype":"%s"}`,
+			i+1, i+1, i, map[bool]string{true: "hidden", false: "public"}[i%2 == 1])
+	}
+
+	return `{"response":{"total":` + fmt.Sprint(n) + `,"extra":{"a":[1,2,{"b":null}]},"servers":[` +
+		strings.Join(servers, ",") + `],"more":true}}`
+}
+
+func collectServers(t *testing.T, client *Client, filter *GetServerListFilter) ([]string, error) {
+	t.Helper()
+
+	var names []string
+
+	for server, err := range client.StreamServerList(context.Background(), filter) {
+		if err != nil {
+			return names, err
+		}
+
+		names = append(names, server.Name)
+	}
+
+	return names, nil
+}
+
+func TestClient_StreamServerList(t *testing.T) {
+	newClient := func(body string, opts ...func(*Config)) *Client {
+		cfg := newConfig("http://steam.test")
+		for _, opt := range opts {
+			opt(cfg)
+		}
+
+		return NewClient(cfg, WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, body), nil
+		})))
+	}
+
+	t.Run("all servers in response order", func(t *testing.T) {
+		names, err := collectServers(t, newClient(serverListBody(4)), &GetServerListFilter{AppID: 108600})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Server 1", "Server 2", "Server 3", "Server 4"}, names)
+	})
+
+	t.Run("custom filters", func(t *testing.T) {
+		names, err := collectServers(t, newClient(serverListBody(4)), &GetServerListFilter{AppID: 108600, NoHidden: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Server 1", "Server 3"}, names)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		count := 0
+
+		for _, err := range newClient(serverListBody(100)).StreamServerList(context.Background(), &GetServerListFilter{AppID: 108600}) {
+			require.NoError(t, err)
+
+			count++
+			if count == 3 {
+				break
+			}
+		}
+
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		names, err := collectServers(t, newClient(`{"response":{}}`), &GetServerListFilter{AppID: 108600})
+		require.NoError(t, err)
+		assert.Empty(t, names)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		names, err := collectServers(t, newClient(`{"response":{"servers":{}}}`), &GetServerListFilter{AppID: 108600})
+		require.ErrorIs(t, err, ErrUnexpectedResponse)
+		assert.Empty(t, names)
+
+		names, err = collectServers(t, newClient(serverListBody(3)[:170]), &GetServerListFilter{AppID: 108600})
+		require.Error(t, err)
+		assert.Equal(t, []string{"Server 1"}, names)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		body := serverListBody(1000)
+
+		names, err := collectServers(t, newClient(body, func(cfg *Config) { cfg.MaxStreamSize = int64(len(body) / 2) }),
+			&GetServerListFilter{AppID: 108600})
+		require.ErrorIs(t, err, ErrResponseTooLarge)
+		assert.NotEmpty(t, names)
+		assert.Less(t, len(names), 1000)
+
+		names, err = collectServers(t, newClient(body, func(cfg *Config) { cfg.MaxStreamSize = int64(len(body)) }),
+			&GetServerListFilter{AppID: 108600})
+		require.NoError(t, err)
+		assert.Len(t, names, 1000)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		names, err := collectServers(t, newClient(serverListBody(4), func(cfg *Config) { cfg.Disabled = true }),
+			&GetServerListFilter{AppID: 108600})
+		require.NoError(t, err)
+		assert.Empty(t, names)
+	})
+}
@@ -0,0 +1,174 @@
+package steamweb
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Tags is a set of server tags reported in Server.GameType (sv_tags) in the
+// order they are reported. Tags are matched exactly, "nothidden" is not
+// "hidden".
+type Tags []string
+
+// ParseTags splits tags separated by commas or semicolons, trims spaces and
+// drops empty and repeated tags.
+func ParseTags(gameType string) Tags {
+	fields := strings.FieldsFunc(gameType, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+
+	tags := make(Tags, 0, len(fields))
+
+	for _, field := range fields {
+		tag := strings.TrimSpace(field)
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// Tags returns parsed GameType of the server.
+func (s *Server) Tags() Tags {
+	return ParseTags(s.GameType)
+}
+
+// Has reports whether the tag is in the set.
+func (t Tags) Has(tag string) bool {
+	return slices.Contains(t, tag)
+}
+
+// HasAll reports whether all of the tags are in the set.
+func (t Tags) HasAll(tags ...string) bool {
+	for _, tag := range tags {
+		if !t.Has(tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasAny reports whether any of the tags is in the set.
+func (t Tags) HasAny(tags ...string) bool {
+	return slices.ContainsFunc(tags, t.Has)
+}
+
+// Value returns the value of the first key:value or key=value tag with the key.
+func (t Tags) Value(key string) (string, bool) {
+	for _, tag := range t {
+		if k, v, ok := splitTag(tag); ok && k == key {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// String joins tags with commas as sv_tags does.
+func (t Tags) String() string {
+	return strings.Join(t, ",")
+}
+
+// splitTag splits key:value or key=value tag.
+func splitTag(tag string) (string, string, bool) {
+	i := strings.IndexAny(tag, ":=")
+	if i <= 0 {
+		return "", "", false
+	}
+
+	return tag[:i], tag[i+1:], true
+}
+
+// TagInfo is game-specific data decoded from server tags.
+type TagInfo struct {
+	// Flags are tags without values.
+	Flags []string `json:"flags,omitempty"`
+
+	// Values are key:value tags, conventional flags may be decoded as values.
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// Flag reports whether the flag is set.
+func (i TagInfo) Flag(name string) bool {
+	return slices.Contains(i.Flags, name)
+}
+
+// Int returns the value parsed as integer.
+func (i TagInfo) Int(key string) (int, bool) {
+	n, err := strconv.Atoi(i.Values[key])
+
+	return n, err == nil
+}
+
+// TagDecoder decodes tags following a game convention.
+type TagDecoder func(tags Tags) TagInfo
+
+// DecodeKeyValueTags decodes key:value and key=value tags into Values,
+// other tags are Flags.
+func DecodeKeyValueTags(tags Tags) TagInfo {
+	info := TagInfo{Values: make(map[string]string)}
+
+	for _, tag := range tags {
+		if key, value, ok := splitTag(tag); ok {
+			info.Values[key] = value
+		} else {
+			info.Flags = append(info.Flags, tag)
+		}
+	}
+
+	return info
+}
+
+// DecodeSourceTags decodes sv_tags of Source engine games. Source tags are
+// case-insensitive flags like "increased_maxplayers" or "valve_ds", they are
+// lowercased.
+func DecodeSourceTags(tags Tags) TagInfo {
+	lower := make(Tags, 0, len(tags))
+
+	for _, tag := range tags {
+		if tag = strings.ToLower(tag); !lower.Has(tag) {
+			lower = append(lower, tag)
+		}
+	}
+
+	return DecodeKeyValueTags(lower)
+}
+
+// DecodeZomboidTags decodes Project Zomboid tags. The server reports
+// "hidden" when it is not listed in the in-game browser, it is also decoded
+// as "visibility" value. Other flags like "hosted" are kept as they are,
+// whether the server is dedicated is reported by Server.Dedicated.
+func DecodeZomboidTags(tags Tags) TagInfo {
+	info := DecodeKeyValueTags(tags)
+
+	info.Values["visibility"] = "public"
+	if info.Flag("hidden") {
+		info.Values["visibility"] = "hidden"
+	}
+
+	return info
+}
+
+// TagDecodersByApp are tag decoders of games by Steam AppID, other games
+// use DecodeKeyValueTags.
+var TagDecodersByApp = map[int]TagDecoder{
+	240:    DecodeSourceTags, // Counter-Strike: Source.
+	440:    DecodeSourceTags, // Team Fortress 2.
+	550:    DecodeSourceTags, // Left 4 Dead 2.
+	730:    DecodeSourceTags, // Counter-Strike 2.
+	4000:   DecodeSourceTags, // Garry's Mod.
+	108600: DecodeZomboidTags,
+}
+
+// DecodeTags decodes tags of the server with the decoder of its AppID.
+func (s *Server) DecodeTags() TagInfo {
+	decode, ok := TagDecodersByApp[s.AppID]
+	if !ok {
+		decode = DecodeKeyValueTags
+	}
+
+	return decode(s.Tags())
+}
@@ -0,0 +1,88 @@
+package steamweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	tags := ParseTags(" hidden; hosted,pvp,,hosted ; mode:survival;day=12")
+
+	assert.Equal(t, Tags{"hidden", "hosted", "pvp", "mode:survival", "day=12"}, tags)
+	assert.Equal(t, "hidden,hosted,pvp,mode:survival,day=12", tags.String())
+	assert.Empty(t, ParseTags(""))
+
+	assert.True(t, tags.Has("hidden"))
+	assert.False(t, ParseTags("nothidden").Has("hidden"))
+	assert.True(t, tags.HasAll("pvp", "hosted"))
+	assert.True(t, tags.HasAll())
+	assert.False(t, tags.HasAll("pvp", "pve"))
+	assert.True(t, tags.HasAny("pve", "pvp"))
+	assert.False(t, tags.HasAny())
+
+	value, ok := tags.Value("mode")
+	assert.True(t, ok)
+	assert.Equal(t, "survival", value)
+
+	value, ok = tags.Value("day")
+	assert.True(t, ok)
+	assert.Equal(t, "12", value)
+
+	_, ok = tags.Value("pvp")
+	assert.False(t, ok)
+}
+
+func TestServer_DecodeTags(t *testing.T) {
+	t.Run("zomboid", func(t *testing.T) {
+		info := (&Server{AppID: 108600, GameType: "hidden;hosted"}).DecodeTags()
+
+		assert.Equal(t, TagInfo{
+			Flags:  []string{"hidden", "hosted"},
+			Values: map[string]string{"visibility": "hidden"},
+		}, info)
+		assert.True(t, info.Flag("hidden"))
+
+		info = (&Server{AppID: 108600}).DecodeTags()
+		assert.Equal(t, map[string]string{"visibility": "public"}, info.Values)
+	})
+
+	t.Run("source", func(t *testing.T) {
+		info := (&Server{AppID: 440, GameType: "CP,increased_maxplayers,cp"}).DecodeTags()
+
+		assert.Equal(t, []string{"cp", "increased_maxplayers"}, info.Flags)
+	})
+
+	t.Run("key value", func(t *testing.T) {
+		info := (&Server{AppID: 1, GameType: "mp:100,queue=3,modded"}).DecodeTags()
+
+		assert.Equal(t, []string{"modded"}, info.Flags)
+
+		n, ok := info.Int("mp")
+		assert.True(t, ok)
+		assert.Equal(t, 100, n)
+
+		_, ok = info.Int("missing")
+		assert.False(t, ok)
+	})
+}
+
+func TestGetServerListFilter_Tags(t *testing.T) {
+	filter := &GetServerListFilter{AppID: 108600, GameTypeTags: []string{"pvp"}, NotGameTypeTags: []string{"hidden", "modded"}}
+	assert.Equal(t, `\appid\108600\gametype\pvp\nor\2\gametype\hidden\gametype\modded`, filter.String())
+
+	servers := []Server{
+		{Addr: "1", GameType: "pvp", Players: 4},
+		{Addr: "2", GameType: "pvp;hidden", Players: 3},
+		{Addr: "3", GameType: "pvp,nothidden", Players: 2},
+		{Addr: "4", GameType: "pve", Players: 1},
+	}
+
+	client := NewClient(newConfig(""))
+
+	got := client.filterServers(append([]Server(nil), servers...), filter)
+	assert.Equal(t, []Server{servers[0], servers[2]}, got)
+
+	got = client.filterServers(append([]Server(nil), servers...), &GetServerListFilter{AppID: 108600, NoHidden: true})
+	assert.Equal(t, []Server{servers[0], servers[2], servers[3]}, got)
+}
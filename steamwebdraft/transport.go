@@ -0,0 +1,165 @@
+package steamweb
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Validate checks proxy URL, connection pool settings and that
+// the configured certificates can be loaded.
+func (t *Transport) Validate() error {
+	var errs []error
+
+	if t.Proxy != "" {
+		if _, err := t.proxyURL(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if t.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "transport.max_idle_conns"))
+	}
+
+	if t.MaxIdleConnsPerHost < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "transport.max_idle_conns_per_host"))
+	}
+
+	if t.IdleConnTimeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "transport.idle_conn_timeout"))
+	}
+
+	if t.ResponseHeaderTimeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative", ErrConfigInvalidParam, "transport.response_header_timeout"))
+	}
+
+	if _, err := t.TLS.config(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *Transport) SetDefaults() {
+	if t.TLSHandshakeTimeout == 0 {
+		t.TLSHandshakeTimeout = DefaultTLSHandshakeTimeout
+	}
+
+	if t.Dialer.Timeout == 0 {
+		t.Dialer.Timeout = DefaultDialerTimeout
+	}
+
+	if t.MaxIdleConns == 0 {
+		t.MaxIdleConns = DefaultMaxIdleConns
+	}
+
+	if t.MaxIdleConnsPerHost == 0 {
+		t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	if t.IdleConnTimeout == 0 {
+		t.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+}
+
+// newHTTPTransport builds http.Transport from the settings.
+func (t *Transport) newHTTPTransport() (*http.Transport, error) {
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:       t.Dialer.Timeout,
+			Deadline:      t.Dialer.Deadline,
+			FallbackDelay: t.Dialer.FallbackDelay,
+			KeepAlive:     t.Dialer.KeepAlive,
+		}).DialContext,
+		TLSHandshakeTimeout:   t.TLSHandshakeTimeout,
+		MaxIdleConns:          t.MaxIdleConns,
+		MaxIdleConnsPerHost:   t.MaxIdleConnsPerHost,
+		IdleConnTimeout:       t.IdleConnTimeout,
+		ResponseHeaderTimeout: t.ResponseHeaderTimeout,
+		ForceAttemptHTTP2:     t.EnableHTTP2,
+	}
+
+	if !t.EnableHTTP2 {
+		// A non-nil empty map disables HTTP/2 negotiation.
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	if t.Proxy != "" {
+		proxy, err := t.proxyURL()
+		if err != nil {
+			return nil, err
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig, err := t.TLS.config()
+	if err != nil {
+		return nil, err
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+func (t *Transport) proxyURL() (*url.URL, error) {
+	proxy, err := url.Parse(t.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrConfigInvalidParam, "transport.proxy", err)
+	}
+
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("%w: %s: unsupported scheme %q", ErrConfigInvalidParam, "transport.proxy", proxy.Scheme)
+	}
+
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("%w: %s: host is empty", ErrConfigInvalidParam, "transport.proxy")
+	}
+
+	return proxy, nil
+}
+
+// config loads certificates and returns tls.Config, or nil when
+// no custom certificates are configured.
+func (t *TLS) config() (*tls.Config, error) {
+	if t.CAFile == "" && t.CertFile == "" && t.KeyFile == "" {
+		return nil, nil //nolint:nilnil // Default TLS settings.
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrConfigInvalidParam, "transport.tls.ca_file", err)
+		}
+
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s: no certificates found", ErrConfigInvalidParam, "transport.tls.ca_file")
+		}
+	}
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, fmt.Errorf("%w: %s", ErrConfigInvalidParam, "transport.tls.cert_file and transport.tls.key_file must be set together")
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrConfigInvalidParam, "transport.tls.cert_file", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,303 @@
+package steamweb
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+)
+
+const (
+	DefaultWatchInterval = time.Minute
+	MinWatchInterval     = time.Second
+)
+
+type (
+	// ServerLister lists servers matching the filter, it is implemented by Client.
+	ServerLister interface {
+		GetServerListContext(ctx context.Context, filter *GetServerListFilter) ([]Server, error)
+	}
+
+	// Clock is a source of time of ServerWatcher, it can be replaced in tests.
+	Clock interface {
+		Now() time.Time
+		After(d time.Duration) <-chan time.Time
+	}
+
+	// ServerEvent is one of ServerAdded, ServerRemoved, ServerChanged
+	// or ServerWatchError.
+	ServerEvent interface {
+		serverEvent()
+	}
+
+	// ServerAdded is sent when a server appears in the list. All servers
+	// of the first list are reported as added.
+	ServerAdded struct {
+		Time   time.Time
+		Server Server
+	}
+
+	// ServerRemoved is sent when a server disappears from the list.
+	ServerRemoved struct {
+		Time   time.Time
+		Server Server
+	}
+
+	// ServerChanged is sent when watched fields of a server change.
+	ServerChanged struct {
+		Time    time.Time
+		Old     Server
+		New     Server
+		Changes []FieldChange
+
+		// Thresholds are player thresholds crossed by the change of players.
+		Thresholds []int
+	}
+
+	// FieldChange is a change of a server field named by its JSON name.
+	FieldChange struct {
+		Field string
+		Old   any
+		New   any
+	}
+
+	// ServerWatchError is sent when the list could not be fetched. The watcher
+	// keeps the previous list and tries again after the interval.
+	ServerWatchError struct {
+		Time time.Time
+		Err  error
+	}
+)
+
+func (ServerAdded) serverEvent()      {}
+func (ServerRemoved) serverEvent()    {}
+func (ServerChanged) serverEvent()    {}
+func (ServerWatchError) serverEvent() {}
+
+// watchedFields are compared to detect changed servers. Players are reported
+// only when they cross a threshold.
+var watchedFields = []struct {
+	name  string
+	value func(server *Server) any
+}{
+	{name: "name", value: func(s *Server) any { return s.Name }},
+	{name: "map", value: func(s *Server) any { return s.Map }},
+	{name: "version", value: func(s *Server) any { return s.Version }},
+	{name: "max_players", value: func(s *Server) any { return s.MaxPlayers }},
+	{name: "secure", value: func(s *Server) any { return s.Secure }},
+	{name: "dedicated", value: func(s *Server) any { return s.Dedicated }},
+	{name: "os", value: func(s *Server) any { return s.OS }},
+	{name: "gametype", value: func(s *Server) any { return s.GameType }},
+}
+
+// ServerWatcher periodically lists servers and reports differences
+// between consecutive lists as events.
+type ServerWatcher struct {
+	lister   ServerLister
+	filter   *GetServerListFilter
+	interval time.Duration
+	clock    Clock
+
+	thresholds   []int
+	keyBySteamID bool
+
+	events chan ServerEvent
+}
+
+// WatcherOption configures ServerWatcher.
+type WatcherOption func(*ServerWatcher)
+
+// WithWatchInterval sets a time between lists. Intervals shorter than
+// MinWatchInterval, including zero and negative ones, are raised to it.
+// The default is 1 minute.
+func WithWatchInterval(interval time.Duration) WatcherOption {
+	return func(w *ServerWatcher) {
+		w.interval = max(interval, MinWatchInterval)
+	}
+}
+
+// WithClock replaces the system clock.
+func WithClock(clock Clock) WatcherOption {
+	return func(w *ServerWatcher) {
+		w.clock = clock
+	}
+}
+
+// WithPlayerThresholds reports players change when it crosses one of
+// the thresholds, e.g. the server got at least 10 players or less than 10.
+// Changes of players are not reported without thresholds.
+func WithPlayerThresholds(thresholds ...int) WatcherOption {
+	return func(w *ServerWatcher) {
+		w.thresholds = thresholds
+	}
+}
+
+// WithKeyBySteamID identifies servers by SteamID instead of Addr, so a server
+// moved to another address is reported as changed. Servers without SteamID
+// are still identified by Addr.
+func WithKeyBySteamID() WatcherOption {
+	return func(w *ServerWatcher) {
+		w.keyBySteamID = true
+	}
+}
+
+// WithEventBuffer sets capacity of the events channel.
+// The default is unbuffered channel.
+func WithEventBuffer(size int) WatcherOption {
+	return func(w *ServerWatcher) {
+		w.events = make(chan ServerEvent, size)
+	}
+}
+
+// NewServerWatcher creates ServerWatcher listing servers with the filter.
+func NewServerWatcher(lister ServerLister, filter *GetServerListFilter, opts ...WatcherOption) *ServerWatcher {
+	watcher := &ServerWatcher{
+		lister:   lister,
+		filter:   filter,
+		interval: DefaultWatchInterval,
+		clock:    SystemClock{},
+		events:   make(chan ServerEvent),
+	}
+
+	for _, opt := range opts {
+		opt(watcher)
+	}
+
+	return watcher
+}
+
+// Events returns channel of events, it is closed when Run returns.
+func (w *ServerWatcher) Events() <-chan ServerEvent {
+	return w.events
+}
+
+// Run lists servers until ctx is done and returns its error. It must be
+// called once, events must be received while it runs.
+func (w *ServerWatcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	var previous map[string]Server
+
+	for {
+		servers, err := w.lister.GetServerListContext(ctx, w.filter)
+		now := w.clock.Now()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if !w.send(ctx, ServerWatchError{Time: now, Err: err}) {
+				return ctx.Err()
+			}
+		} else {
+			current := w.index(servers)
+
+			for _, event := range w.diff(now, previous, current) {
+				if !w.send(ctx, event) {
+					return ctx.Err()
+				}
+			}
+
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.clock.After(w.interval):
+		}
+	}
+}
+
+func (w *ServerWatcher) send(ctx context.Context, event ServerEvent) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *ServerWatcher) index(servers []Server) map[string]Server {
+	index := make(map[string]Server, len(servers))
+
+	for _, server := range servers {
+		key := server.Addr
+		if w.keyBySteamID && server.SteamID != "" {
+			key = server.SteamID
+		}
+
+		index[key] = server
+	}
+
+	return index
+}
+
+// diff returns events turning previous list into current one ordered by
+// server keys: removed servers first, then added and changed ones.
+func (w *ServerWatcher) diff(now time.Time, previous, current map[string]Server) []ServerEvent {
+	var removed, updated []ServerEvent
+
+	for _, key := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, ServerRemoved{Time: now, Server: previous[key]})
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(current)) {
+		server := current[key]
+
+		old, ok := previous[key]
+		if !ok {
+			updated = append(updated, ServerAdded{Time: now, Server: server})
+
+			continue
+		}
+
+		if event, changed := w.compare(now, &old, &server); changed {
+			updated = append(updated, event)
+		}
+	}
+
+	return append(removed, updated...)
+}
+
+func (w *ServerWatcher) compare(now time.Time, old, server *Server) (ServerChanged, bool) {
+	event := ServerChanged{Time: now, Old: *old, New: *server}
+
+	if old.Addr != server.Addr {
+		event.Changes = append(event.Changes, FieldChange{Field: "addr", Old: old.Addr, New: server.Addr})
+	}
+
+	for _, field := range watchedFields {
+		if oldValue, newValue := field.value(old), field.value(server); oldValue != newValue {
+			event.Changes = append(event.Changes, FieldChange{Field: field.name, Old: oldValue, New: newValue})
+		}
+	}
+
+	for _, threshold := range w.thresholds {
+		if (old.Players < threshold) != (server.Players < threshold) {
+			event.Thresholds = append(event.Thresholds, threshold)
+		}
+	}
+
+	if len(event.Thresholds) != 0 {
+		event.Changes = append(event.Changes, FieldChange{Field: "players", Old: old.Players, New: server.Players})
+	}
+
+	return event, len(event.Changes) != 0
+}
+
+// SystemClock is Clock using the time package.
+type SystemClock struct{}
+
+// Now returns time.Now.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After returns time.After.
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
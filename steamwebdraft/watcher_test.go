@@ -0,0 +1,283 @@
+package steamweb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock fires After channels when it is advanced.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		waiting: make(chan struct{}, 100),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	c.waiting <- struct{}{}
+
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	timers := c.timers[:0]
+
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			timers = append(timers, timer)
+
+			continue
+		}
+
+		timer.ch <- c.now
+	}
+
+	c.timers = timers
+}
+
+// waitTimer blocks until the watcher waits for the clock.
+func (c *fakeClock) waitTimer(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-c.waiting:
+	case <-time.After(time.Second):
+		t.Fatal("watcher does not wait for the clock")
+	}
+}
+
+type listerFunc func(ctx context.Context, filter *GetServerListFilter) ([]Server, error)
+
+func (fn listerFunc) GetServerListContext(ctx context.Context, filter *GetServerListFilter) ([]Server, error) {
+	return fn(ctx, filter)
+}
+
+// sequenceLister returns responses one after another.
+func sequenceLister(responses ...any) ServerLister {
+	var (
+		mu   sync.Mutex
+		call int
+	)
+
+	return listerFunc(func(context.Context, *GetServerListFilter) ([]Server, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		response := responses[min(call, len(responses)-1)]
+		call++
+
+		if err, ok := response.(error); ok {
+			return nil, err
+		}
+
+		servers, _ := response.([]Server)
+
+		return servers, nil
+	})
+}
+
+func receiveEvents(t *testing.T, watcher *ServerWatcher, n int) []ServerEvent {
+	t.Helper()
+
+	events := make([]ServerEvent, 0, n)
+
+	for range n {
+		select {
+		case event := <-watcher.Events():
+			events = append(events, event)
+		case <-time.After(time.Second):
+			t.Fatalf("got %d events, want %d", len(events), n)
+		}
+	}
+
+	return events
+}
+
+func TestServerWatcher(t *testing.T) {
+	errSteam := errors.New("steam is down")
+
+	first := []Server{
+		{Addr: "127.0.0.1:1", SteamID: "1", Name: "Alpha", Map: "Muldraugh, KY", Version: "41.78", Players: 5},
+		{Addr: "127.0.0.2:1", SteamID: "2", Name: "Bravo", Players: 1},
+	}
+	second := []Server{
+		{Addr: "127.0.0.1:1", SteamID: "1", Name: "Alpha", Map: "Riverside, KY", Version: "41.78.16", Players: 12},
+		{Addr: "127.0.0.3:1", SteamID: "3", Name: "Charlie", Players: 0},
+	}
+	third := []Server{
+		{Addr: "127.0.0.1:1", SteamID: "1", Name: "Alpha", Map: "Riverside, KY", Version: "41.78.16", Players: 11},
+		{Addr: "127.0.0.3:1", SteamID: "3", Name: "Charlie", Players: 3},
+	}
+
+	clock := newFakeClock()
+	watcher := NewServerWatcher(sequenceLister(first, second, errSteam, third), &GetServerListFilter{AppID: 108600},
+		WithClock(clock), WithWatchInterval(time.Minute), WithPlayerThresholds(10))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+
+	start := clock.Now()
+
+	assert.Equal(t, []ServerEvent{
+		ServerAdded{Time: start, Server: first[0]},
+		ServerAdded{Time: start, Server: first[1]},
+	}, receiveEvents(t, watcher, 2))
+
+	clock.waitTimer(t)
+	clock.Advance(time.Minute)
+
+	assert.Equal(t, []ServerEvent{
+		ServerRemoved{Time: start.Add(time.Minute), Server: first[1]},
+		ServerChanged{
+			Time: start.Add(time.Minute),
+			Old:  first[0],
+			New:  second[0],
+			Changes: []FieldChange{
+				{Field: "map", Old: "Muldraugh, KY", New: "Riverside, KY"},
+				{Field: "version", Old: "41.78", New: "41.78.16"},
+				{Field: "players", Old: 5, New: 12},
+			},
+			Thresholds: []int{10},
+		},
+		ServerAdded{Time: start.Add(time.Minute), Server: second[1]},
+	}, receiveEvents(t, watcher, 3))
+
+	clock.waitTimer(t)
+	clock.Advance(time.Minute)
+
+	assert.Equal(t, []ServerEvent{ServerWatchError{Time: start.Add(2 * time.Minute), Err: errSteam}}, receiveEvents(t, watcher, 1))
+
+	// Players changed without crossing thresholds, nothing is reported.
+	clock.waitTimer(t)
+	clock.Advance(time.Minute)
+	clock.waitTimer(t)
+
+	select {
+	case event := <-watcher.Events():
+		t.Fatalf("unexpected event %#v", event)
+	default:
+	}
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	_, ok := <-watcher.Events()
+	assert.False(t, ok)
+}
+
+func TestServerWatcher_KeyBySteamID(t *testing.T) {
+	moved := []Server{{Addr: "127.0.0.2:1", SteamID: "1", Name: "Alpha"}}
+	servers := []Server{{Addr: "127.0.0.1:1", SteamID: "1", Name: "Alpha"}}
+
+	for _, tt := range []struct {
+		name string
+		opts []WatcherOption
+		want func(now time.Time) []ServerEvent
+	}{
+		{
+			name: "addr",
+			want: func(now time.Time) []ServerEvent {
+				return []ServerEvent{ServerRemoved{Time: now, Server: servers[0]}, ServerAdded{Time: now, Server: moved[0]}}
+			},
+		},
+		{
+			name: "steam id",
+			opts: []WatcherOption{WithKeyBySteamID()},
+			want: func(now time.Time) []ServerEvent {
+				return []ServerEvent{ServerChanged{
+					Time: now, Old: servers[0], New: moved[0],
+					Changes: []FieldChange{{Field: "addr", Old: "127.0.0.1:1", New: "127.0.0.2:1"}},
+				}}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			watcher := NewServerWatcher(sequenceLister(servers, moved), &GetServerListFilter{},
+				append(tt.opts, WithClock(clock), WithEventBuffer(10))...)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go watcher.Run(ctx) //nolint:errcheck // Canceled by the test.
+
+			receiveEvents(t, watcher, 1)
+			clock.waitTimer(t)
+			clock.Advance(DefaultWatchInterval)
+
+			events := receiveEvents(t, watcher, len(tt.want(time.Time{})))
+			assert.Equal(t, tt.want(clock.Now()), events)
+		})
+	}
+}
+
+func TestWithWatchInterval(t *testing.T) {
+	for _, tt := range []struct {
+		interval, want time.Duration
+	}{
+		{interval: 0, want: MinWatchInterval},
+		{interval: -time.Minute, want: MinWatchInterval},
+		{interval: time.Millisecond, want: MinWatchInterval},
+		{interval: 5 * time.Second, want: 5 * time.Second},
+	} {
+		watcher := NewServerWatcher(sequenceLister([]Server{}), &GetServerListFilter{}, WithWatchInterval(tt.interval))
+		assert.Equal(t, tt.want, watcher.interval, tt.interval)
+	}
+
+	t.Run("run waits", func(t *testing.T) {
+		clock := newFakeClock()
+		watcher := NewServerWatcher(sequenceLister([]Server{}), &GetServerListFilter{}, WithClock(clock), WithWatchInterval(0))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go watcher.Run(ctx) //nolint:errcheck // Canceled by the test.
+
+		clock.waitTimer(t)
+
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+
+		require.Len(t, clock.timers, 1)
+		assert.Equal(t, clock.now.Add(MinWatchInterval), clock.timers[0].at)
+	})
+}